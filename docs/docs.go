@@ -15,6 +15,123 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/api/v1/board": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Fetch the queue numbers and statuses of today's Accepted, Preparing and Ready orders per Shop. No other order detail is exposed",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Queue Board"
+                ],
+                "summary": "Fetch Queue Board",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Shop ID",
+                        "name": "shop_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/ginlib.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.BoardShop"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Shop not found",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/board/events": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Stream the queue board as Server-Sent Events. The stream starts with one board.updated event per Shop and sends the full board of a Shop again every time it changes",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Queue Board"
+                ],
+                "summary": "Stream Queue Board",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Shop ID",
+                        "name": "shop_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Event stream",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Shop not found",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    }
+                }
+            }
+        },
         "/api/v1/menus": {
             "get": {
                 "security": [
@@ -22,7 +139,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Fetch All Menus From Database",
+                "description": "Fetch All Menus From Database, with group=category and a shop_id the data is a list of domain.MenuSection instead",
                 "produces": [
                     "application/json"
                 ],
@@ -36,6 +153,75 @@ const docTemplate = `{
                         "description": "Shop ID",
                         "name": "shop_id",
                         "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum price",
+                        "name": "min_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum price",
+                        "name": "max_price",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "available",
+                            "sold_out"
+                        ],
+                        "type": "string",
+                        "description": "Menu status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search menu name",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "newest",
+                            "price_asc",
+                            "price_desc",
+                            "name_asc",
+                            "name_desc",
+                            "popular"
+                        ],
+                        "type": "string",
+                        "description": "Sort order, defaults to newest",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "category"
+                        ],
+                        "type": "string",
+                        "description": "Group a shop menu into category sections, requires shop_id",
+                        "name": "group",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, defaults to 20 and capped at 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the next page, also accepted as the X-Cursor header",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a previously fetched response",
+                        "name": "If-None-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -58,6 +244,21 @@ const docTemplate = `{
                                     }
                                 }
                             ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the response for If-None-Match"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified, the cached response is still current"
+                    },
+                    "400": {
+                        "description": "Invalid filter, limit or cursor",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
                         }
                     },
                     "500": {
@@ -77,7 +278,11 @@ const docTemplate = `{
                         "UserAuth": []
                     }
                 ],
-                "description": "Update Existing Menu",
+                "description": "Update Existing Menu, photo can be replaced with multipart/form-data. shop_id must be the current shop of the menu",
+                "consumes": [
+                    "application/json",
+                    "multipart/form-data"
+                ],
                 "produces": [
                     "application/json"
                 ],
@@ -101,6 +306,13 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the item as last fetched",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -110,12 +322,48 @@ const docTemplate = `{
                             "$ref": "#/definitions/ginlib.Response"
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
                     "404": {
                         "description": "Item not found",
                         "schema": {
                             "$ref": "#/definitions/ginlib.Response"
                         }
                     },
+                    "412": {
+                        "description": "Item was changed since it was fetched",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "413": {
+                        "description": "File too large",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported file type",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header is missing",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -133,7 +381,11 @@ const docTemplate = `{
                         "UserAuth": []
                     }
                 ],
-                "description": "Register Menu to System",
+                "description": "Register Menu to System, photo can be uploaded with multipart/form-data",
+                "consumes": [
+                    "application/json",
+                    "multipart/form-data"
+                ],
                 "produces": [
                     "application/json"
                 ],
@@ -159,6 +411,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/ginlib.Response"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "413": {
+                        "description": "File too large",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported file type",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -200,6 +470,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/ginlib.Response"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
                     "404": {
                         "description": "Item not found",
                         "schema": {
@@ -237,6 +513,12 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a previously fetched response",
+                        "name": "If-None-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -256,8 +538,17 @@ const docTemplate = `{
                                     }
                                 }
                             ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the item, for If-None-Match and for If-Match on updates"
+                            }
                         }
                     },
+                    "304": {
+                        "description": "Not Modified, the cached response is still current"
+                    },
                     "404": {
                         "description": "Item not found",
                         "schema": {
@@ -273,8 +564,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/orders": {
-            "get": {
+        "/api/v1/menus/{id}/options": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -283,85 +574,40 @@ const docTemplate = `{
                         "UserAuth": []
                     }
                 ],
-                "description": "Fetch All Orders From Database",
-                "produces": [
+                "description": "Replace every option group of a Menu, like spice level or extra toppings",
+                "consumes": [
                     "application/json"
                 ],
-                "tags": [
-                    "Orders"
-                ],
-                "summary": "Fetch All Orders",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Shop ID",
-                        "name": "shop_id",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/ginlib.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/domain.Order"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/ginlib.Response"
-                        }
-                    }
-                }
-            },
-            "put": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    },
-                    {
-                        "UserAuth": []
-                    }
-                ],
-                "description": "Update Existing Order",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Orders (Admin and Owner)"
+                    "Menus (Admin and Owner)"
                 ],
-                "summary": "Update Order",
+                "summary": "Update Menu Options",
                 "parameters": [
                     {
-                        "description": "Order Update Payload",
-                        "name": "OrderPayload",
+                        "description": "Menu Options Payload",
+                        "name": "OptionsPayload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.OrderRequest"
+                            "$ref": "#/definitions/dto.MenuOptionsRequest"
                         }
                     },
                     {
                         "type": "string",
-                        "description": "Order ID",
+                        "description": "Menu ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the menu as last fetched",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -371,8 +617,32 @@ const docTemplate = `{
                             "$ref": "#/definitions/ginlib.Response"
                         }
                     },
-                    "404": {
-                        "description": "Item not found",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Item not found",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "Item was changed since it was fetched",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header is missing",
                         "schema": {
                             "$ref": "#/definitions/ginlib.Response"
                         }
@@ -384,8 +654,10 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/api/v1/orders": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -394,28 +666,64 @@ const docTemplate = `{
                         "UserAuth": []
                     }
                 ],
-                "description": "Register Order to System",
+                "description": "Fetch All Orders From Database",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Orders"
                 ],
-                "summary": "Register Order",
+                "summary": "Fetch All Orders",
                 "parameters": [
                     {
-                        "description": "Order Register Payload",
-                        "name": "OrderPayload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.OrderRequest"
-                        }
+                        "type": "string",
+                        "description": "Shop ID",
+                        "name": "shop_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, defaults to 20 and capped at 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the next page, also accepted as the X-Cursor header",
+                        "name": "cursor",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/ginlib.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.Order"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid limit or cursor",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/ginlib.Response"
                         }
@@ -428,7 +736,7 @@ const docTemplate = `{
                     }
                 }
             },
-            "delete": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -437,21 +745,37 @@ const docTemplate = `{
                         "UserAuth": []
                     }
                 ],
-                "description": "Delete Existing Order from System",
+                "description": "Update Existing Order, moving it to Cancelled works like the cancel action and needs a reason",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Orders (Admin and Owner)"
                 ],
-                "summary": "Delete Order",
+                "summary": "Update Order",
                 "parameters": [
+                    {
+                        "description": "Order Update Payload",
+                        "name": "OrderPayload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.OrderUpdateRequest"
+                        }
+                    },
                     {
                         "type": "string",
                         "description": "Order ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the item as last fetched",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -461,12 +785,36 @@ const docTemplate = `{
                             "$ref": "#/definitions/ginlib.Response"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
                     "404": {
                         "description": "Item not found",
                         "schema": {
                             "$ref": "#/definitions/ginlib.Response"
                         }
                     },
+                    "409": {
+                        "description": "Invalid status transition or menu out of stock",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "Item was changed since it was fetched",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header is missing",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -474,10 +822,8 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/orders/{id}": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -486,21 +832,29 @@ const docTemplate = `{
                         "UserAuth": []
                     }
                 ],
-                "description": "Fetch Order By ID From DB",
+                "description": "Register Order with one or more menu items to System",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Orders"
                 ],
-                "summary": "Fetch Order By ID",
+                "summary": "Register Order",
                 "parameters": [
+                    {
+                        "description": "Order Register Payload",
+                        "name": "OrderPayload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.OrderRequest"
+                        }
+                    },
                     {
                         "type": "string",
-                        "description": "Order ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Replays the first response when the same request is sent again with this key",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -522,8 +876,26 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "404": {
-                        "description": "Item not found",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Menu is out of stock, shop is closed or pickup slot is full",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Idempotency key was used for a different request",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded, see Retry-After",
                         "schema": {
                             "$ref": "#/definitions/ginlib.Response"
                         }
@@ -537,41 +909,49 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/owners": {
+        "/api/v1/orders/events": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
+                    },
+                    {
+                        "UserAuth": []
                     }
                 ],
-                "description": "Fetch All Owners From Database",
+                "description": "Stream order changes as Server-Sent Events. Without shop_id the caller receives the events of their own orders, with shop_id owners and admins receive every order event of that Shop. Each event is named after its type (order.created, order.status_changed, order.payment_updated) and carries the order as data",
                 "produces": [
-                    "application/json"
+                    "text/event-stream"
                 ],
                 "tags": [
-                    "Owners"
+                    "Orders"
+                ],
+                "summary": "Stream Order Events",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Shop ID",
+                        "name": "shop_id",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Fetch All Owners",
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Event stream",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/ginlib.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/domain.Owner"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
                         }
                     },
                     "500": {
@@ -581,41 +961,46 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Register Owner to System",
-                "produces": [
-                    "application/json"
-                ],
+            }
+        },
+        "/api/v1/orders/kitchen": {
+            "get": {
+                "description": "Upgrade to a WebSocket, authenticated with a one time ticket and open to browsers from WS_ALLOWED_ORIGINS only, that pushes every order event of the Shop as {\"type\", \"data\"} messages, the same events as the order event stream. The tablet advances orders by sending {\"request_id\", \"action\": \"update_status\", \"order_id\", \"version\", \"status\", \"reason\", \"payment_method\"}, which goes through the same validation and ownership rules as updating an order over http, including the version check of If-Match, and is answered with a {\"type\": \"command.result\", \"request_id\", \"data\"} message holding the usual response body",
                 "tags": [
-                    "Owners"
+                    "Orders (Admin and Owner)"
                 ],
-                "summary": "Register Owner",
+                "summary": "Kitchen Display Channel",
                 "parameters": [
                     {
-                        "description": "Owner Register Payload",
-                        "name": "OwnerPayload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.OwnerRequest"
-                        }
+                        "type": "string",
+                        "description": "Shop ID",
+                        "name": "shop_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ticket from the kitchen ticket route",
+                        "name": "ticket",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "101": {
+                        "description": "Switching Protocols",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request or invalid ticket",
                         "schema": {
                             "$ref": "#/definitions/ginlib.Response"
                         }
                     },
-                    "409": {
-                        "description": "Username already exists",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/ginlib.Response"
                         }
@@ -629,30 +1014,24 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/owners/{id}": {
-            "get": {
+        "/api/v1/orders/kitchen/ticket": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
+                    },
+                    {
+                        "UserAuth": []
                     }
                 ],
-                "description": "Fetch Owner By ID From DB",
+                "description": "Issue a one time ticket to open the kitchen channel with, it expires after 30 seconds",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Owners"
-                ],
-                "summary": "Fetch Owner By ID",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Owner ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Orders (Admin and Owner)"
                 ],
+                "summary": "Issue Kitchen Ticket",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -665,80 +1044,58 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/domain.Owner"
+                                            "$ref": "#/definitions/dto.KitchenTicket"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "404": {
-                        "description": "Item not found",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/ginlib.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/domain.Owner"
-                                        }
-                                    }
-                                }
-                            ]
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/ginlib.Response"
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/api/v1/orders/slots": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
+                    },
+                    {
+                        "UserAuth": []
                     }
                 ],
-                "description": "Update Existing Owner",
+                "description": "Fetch the active pre-orders of a Shop for one day grouped by pickup slot, with the quantities to prepare per slot",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Owners"
+                    "Orders (Admin and Owner)"
                 ],
-                "summary": "Update Owner",
+                "summary": "Fetch Pickup Slots",
                 "parameters": [
                     {
-                        "description": "Owner Register Payload",
-                        "name": "OwnerPayload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.OwnerRequest"
-                        }
+                        "type": "string",
+                        "description": "Shop ID",
+                        "name": "shop_id",
+                        "in": "query",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Owner ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Day as YYYY-MM-DD, defaults to today starting from the current slot",
+                        "name": "date",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/ginlib.Response"
-                        }
-                    },
-                    "404": {
-                        "description": "Item not found",
                         "schema": {
                             "allOf": [
                                 {
@@ -748,59 +1105,24 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/domain.Owner"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.PickupSlot"
+                                            }
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "409": {
-                        "description": "Username already exists",
-                        "schema": {
-                            "$ref": "#/definitions/ginlib.Response"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/ginlib.Response"
-                        }
-                    }
-                }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Delete Existing Owner",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Owners"
-                ],
-                "summary": "Delete Owner",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Owner ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/ginlib.Response"
                         }
                     },
-                    "404": {
-                        "description": "Item not found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/ginlib.Response"
                         }
@@ -814,7 +1136,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/shops": {
+        "/api/v1/orders/{id}": {
             "get": {
                 "security": [
                     {
@@ -824,14 +1146,23 @@ const docTemplate = `{
                         "UserAuth": []
                     }
                 ],
-                "description": "Fetch All Shops From Database",
+                "description": "Fetch Order By ID From DB",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Shops"
+                    "Orders"
+                ],
+                "summary": "Fetch Order By ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Fetch All Shops",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -844,14 +1175,29 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/domain.Shop"
-                                            }
+                                            "$ref": "#/definitions/domain.Order"
                                         }
                                     }
                                 }
                             ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the item, send it as If-Match to update it"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Item not found",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
                         }
                     },
                     "500": {
@@ -861,7 +1207,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/api/v1/orders/{id}/cancel": {
             "post": {
                 "security": [
                     {
@@ -871,23 +1219,32 @@ const docTemplate = `{
                         "UserAuth": []
                     }
                 ],
-                "description": "Register Shop to System",
+                "description": "Cancel an Order while keeping it in the history. Students may cancel their own order only while it is Waiting, owners and admins may cancel any order of their Shop before pickup and must give a reason",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Shops (Admin only)"
+                    "Orders"
                 ],
-                "summary": "Register Shop",
+                "summary": "Cancel Order",
                 "parameters": [
                     {
-                        "description": "Shop Register Payload",
-                        "name": "ShopPayload",
+                        "description": "Cancellation reason, required for owners and admins",
+                        "name": "CancelPayload",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.ShopRequest"
+                            "$ref": "#/definitions/dto.OrderCancelRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -897,6 +1254,30 @@ const docTemplate = `{
                             "$ref": "#/definitions/ginlib.Response"
                         }
                     },
+                    "400": {
+                        "description": "Reason is required",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Item not found",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Order can no longer be cancelled",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -906,7 +1287,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/shops/{id}": {
+        "/api/v1/orders/{id}/history": {
             "get": {
                 "security": [
                     {
@@ -916,18 +1297,18 @@ const docTemplate = `{
                         "UserAuth": []
                     }
                 ],
-                "description": "Fetch Shop By ID From DB",
+                "description": "Fetch Status Transition Timeline of an Order",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Shops"
+                    "Orders"
                 ],
-                "summary": "Fetch Shop By ID",
+                "summary": "Fetch Order Status History",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Shop ID",
+                        "description": "Order ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -945,29 +1326,26 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/domain.Shop"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.OrderStatusHistory"
+                                            }
                                         }
                                     }
                                 }
                             ]
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
                     "404": {
                         "description": "Item not found",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/ginlib.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/domain.Shop"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/ginlib.Response"
                         }
                     },
                     "500": {
@@ -977,8 +1355,10 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/api/v1/orders/{id}/payment-proof": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -987,41 +1367,42 @@ const docTemplate = `{
                         "UserAuth": []
                     }
                 ],
-                "description": "Update Existing Shop",
+                "description": "Upload QRIS Payment Proof Image of a Waiting Order",
+                "consumes": [
+                    "multipart/form-data"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Shops (Admin and Owner)"
+                    "Orders"
                 ],
-                "summary": "Update Shop",
+                "summary": "Upload Payment Proof",
                 "parameters": [
-                    {
-                        "description": "Shop Register Payload",
-                        "name": "ShopPayload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.ShopRequest"
-                        }
-                    },
                     {
                         "type": "string",
-                        "description": "Shop ID",
+                        "description": "Order ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Payment proof image (jpeg, png or webp)",
+                        "name": "payment_proof",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Replays the first response when the same request is sent again with this key",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/ginlib.Response"
-                        }
-                    },
-                    "404": {
-                        "description": "Item not found",
                         "schema": {
                             "allOf": [
                                 {
@@ -1031,15 +1412,57 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/domain.Shop"
+                                            "$ref": "#/definitions/domain.Order"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Item not found",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
                     "409": {
-                        "description": "Username already exists",
+                        "description": "Payment can not be submitted",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "413": {
+                        "description": "File too large",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported file type",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Idempotency key was used for a different request",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded, see Retry-After",
                         "schema": {
                             "$ref": "#/definitions/ginlib.Response"
                         }
@@ -1051,8 +1474,10 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/v1/orders/{id}/payment-proof/approve": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -1061,18 +1486,26 @@ const docTemplate = `{
                         "UserAuth": []
                     }
                 ],
-                "description": "Delete Existing Shop",
+                "description": "Approve Submitted Payment Proof and Accept the Order",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Shops (Admin only)"
+                    "Orders (Admin and Owner)"
                 ],
-                "summary": "Delete Shop",
+                "summary": "Approve Payment",
                 "parameters": [
+                    {
+                        "description": "Optional note for the student",
+                        "name": "PaymentPayload",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PaymentVerificationRequest"
+                        }
+                    },
                     {
                         "type": "string",
-                        "description": "Shop ID",
+                        "description": "Order ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1085,12 +1518,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/ginlib.Response"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
                     "404": {
                         "description": "Item not found",
                         "schema": {
                             "$ref": "#/definitions/ginlib.Response"
                         }
                     },
+                    "409": {
+                        "description": "Payment is not awaiting verification or menu out of stock",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1100,7 +1545,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/shops/{id}/owners/{ownerId}": {
+        "/api/v1/orders/{id}/payment-proof/reject": {
             "post": {
                 "security": [
                     {
@@ -1110,26 +1555,28 @@ const docTemplate = `{
                         "UserAuth": []
                     }
                 ],
-                "description": "Add Owner to Shop",
+                "description": "Reject Submitted Payment Proof with a Reason Shown to the Student",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Shops (Admin only)"
+                    "Orders (Admin and Owner)"
                 ],
-                "summary": "Add Owner to Shop",
+                "summary": "Reject Payment",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Shop ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Rejection reason",
+                        "name": "PaymentPayload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PaymentVerificationRequest"
+                        }
                     },
                     {
                         "type": "string",
-                        "description": "Owner ID",
-                        "name": "ownerId",
+                        "description": "Order ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -1141,8 +1588,92 @@ const docTemplate = `{
                             "$ref": "#/definitions/ginlib.Response"
                         }
                     },
+                    "400": {
+                        "description": "Reason is required",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
                     "404": {
-                        "description": "Shop or Owner not found",
+                        "description": "Item not found",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Payment is not awaiting verification",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/owners": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Fetch All Owners From Database",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Owners"
+                ],
+                "summary": "Fetch All Owners",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page size, defaults to 20 and capped at 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the next page, also accepted as the X-Cursor header",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/ginlib.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.Owner"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid limit or cursor",
                         "schema": {
                             "$ref": "#/definitions/ginlib.Response"
                         }
@@ -1155,37 +1686,165 @@ const docTemplate = `{
                     }
                 }
             },
-            "delete": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Register Owner to System",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Owners"
+                ],
+                "summary": "Register Owner",
+                "parameters": [
+                    {
+                        "description": "Owner Register Payload",
+                        "name": "OwnerPayload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.OwnerRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Username already exists",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
                     },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/owners/{id}": {
+            "get": {
+                "security": [
                     {
-                        "UserAuth": []
+                        "ApiKeyAuth": []
                     }
                 ],
-                "description": "Remove Owner from Shop",
+                "description": "Fetch Owner By ID From DB",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Shops (Admin only)"
+                    "Owners"
                 ],
-                "summary": "Remove Owner from Shop",
+                "summary": "Fetch Owner By ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Shop ID",
+                        "description": "Owner ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/ginlib.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.Owner"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the item, send it as If-Match to update it"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Item not found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/ginlib.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.Owner"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Update Existing Owner",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Owners"
+                ],
+                "summary": "Update Owner",
+                "parameters": [
+                    {
+                        "description": "Owner Register Payload",
+                        "name": "OwnerPayload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.OwnerRequest"
+                        }
                     },
                     {
                         "type": "string",
                         "description": "Owner ID",
-                        "name": "ownerId",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the item as last fetched",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -1195,29 +1854,1692 @@ const docTemplate = `{
                             "$ref": "#/definitions/ginlib.Response"
                         }
                     },
-                    "404": {
-                        "description": "Item not found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/ginlib.Response"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Item not found",
                         "schema": {
-                            "$ref": "#/definitions/ginlib.Response"
-                        }
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/ginlib.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.Owner"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "409": {
+                        "description": "Username already exists",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "Item was changed since it was fetched",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header is missing",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete Existing Owner",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Owners"
+                ],
+                "summary": "Delete Owner",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Owner ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Item not found",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/search": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Full Text and Fuzzy Search over Menu Names, Shop Names and Shop Descriptions, Ranked and Grouped by Shop",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Search"
+                ],
+                "summary": "Search Menus and Shops",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search keywords, at least 2 characters",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum shops returned, defaults to 10 and capped at 30",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/ginlib.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.SearchResult"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded, see Retry-After",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/shops": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "UserAuth": []
+                    }
+                ],
+                "description": "Fetch All Shops From Database",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shops"
+                ],
+                "summary": "Fetch All Shops",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page size, defaults to 20 and capped at 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the next page, also accepted as the X-Cursor header",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a previously fetched response",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/ginlib.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.Shop"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the response for If-None-Match"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified, the cached response is still current"
+                    },
+                    "400": {
+                        "description": "Invalid limit or cursor",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "UserAuth": []
+                    }
+                ],
+                "description": "Register Shop to System, photo can be uploaded with multipart/form-data",
+                "consumes": [
+                    "application/json",
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shops (Admin only)"
+                ],
+                "summary": "Register Shop",
+                "parameters": [
+                    {
+                        "description": "Shop Register Payload",
+                        "name": "ShopPayload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ShopRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "413": {
+                        "description": "File too large",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported file type",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/shops/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "UserAuth": []
+                    }
+                ],
+                "description": "Fetch Shop By ID From DB",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shops"
+                ],
+                "summary": "Fetch Shop By ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Shop ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a previously fetched response",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/ginlib.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.Shop"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version and open state of the item, for If-None-Match and for If-Match on updates"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified, the cached response is still current"
+                    },
+                    "404": {
+                        "description": "Item not found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/ginlib.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.Shop"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "UserAuth": []
+                    }
+                ],
+                "description": "Update Existing Shop, photo can be replaced with multipart/form-data",
+                "consumes": [
+                    "application/json",
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shops (Admin and Owner)"
+                ],
+                "summary": "Update Shop",
+                "parameters": [
+                    {
+                        "description": "Shop Register Payload",
+                        "name": "ShopPayload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ShopRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Shop ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the item as last fetched",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Item not found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/ginlib.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.Shop"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "409": {
+                        "description": "Username already exists",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "Item was changed since it was fetched",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "413": {
+                        "description": "File too large",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported file type",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header is missing",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "UserAuth": []
+                    }
+                ],
+                "description": "Delete Existing Shop",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shops (Admin only)"
+                ],
+                "summary": "Delete Shop",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Shop ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Item not found",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/shops/{id}/categories": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Fetch Menu Categories of a Shop in Display Order",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Menu Categories"
+                ],
+                "summary": "Fetch Shop Menu Categories",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Shop ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/ginlib.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.MenuCategory"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "UserAuth": []
+                    }
+                ],
+                "description": "Create Menu Category for a Shop",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Menu Categories (Admin and Owner)"
+                ],
+                "summary": "Create Menu Category",
+                "parameters": [
+                    {
+                        "description": "Menu Category Payload",
+                        "name": "CategoryPayload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.MenuCategoryRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Shop ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Category name already exists",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/shops/{id}/categories/{categoryId}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "UserAuth": []
+                    }
+                ],
+                "description": "Rename or Reorder a Menu Category",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Menu Categories (Admin and Owner)"
+                ],
+                "summary": "Update Menu Category",
+                "parameters": [
+                    {
+                        "description": "Menu Category Payload",
+                        "name": "CategoryPayload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.MenuCategoryRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Shop ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Category ID",
+                        "name": "categoryId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Item not found",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Category name already exists",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "UserAuth": []
+                    }
+                ],
+                "description": "Delete a Menu Category, its menus become uncategorized",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Menu Categories (Admin and Owner)"
+                ],
+                "summary": "Delete Menu Category",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Shop ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Category ID",
+                        "name": "categoryId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Item not found",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/shops/{id}/closures": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "UserAuth": []
+                    }
+                ],
+                "description": "Close a Shop for a whole day, like a campus holiday",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shop Schedule (Admin and Owner)"
+                ],
+                "summary": "Add Shop Closure",
+                "parameters": [
+                    {
+                        "description": "Closure Payload",
+                        "name": "ClosurePayload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ShopClosureRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Shop ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/ginlib.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.ShopClosure"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Item not found",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Shop is already closed on that date",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/shops/{id}/closures/{closureId}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "UserAuth": []
+                    }
+                ],
+                "description": "Remove a Closure so the Shop follows its opening hours again on that day",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shop Schedule (Admin and Owner)"
+                ],
+                "summary": "Remove Shop Closure",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Shop ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Closure ID",
+                        "name": "closureId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Item not found",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/shops/{id}/hours": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "UserAuth": []
+                    }
+                ],
+                "description": "Replace the weekly opening hours of a Shop, weekday 0 is Sunday and times are HH:MM in Asia/Jakarta. A shop without hours is always open",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shop Schedule (Admin and Owner)"
+                ],
+                "summary": "Update Shop Opening Hours",
+                "parameters": [
+                    {
+                        "description": "Opening Hours Payload",
+                        "name": "HoursPayload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ShopHoursRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Shop ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the shop as last fetched",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Item not found",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "Item was changed since it was fetched",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header is missing",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/shops/{id}/owners/{ownerId}": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "UserAuth": []
+                    }
+                ],
+                "description": "Add Owner to Shop",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shops (Admin only)"
+                ],
+                "summary": "Add Owner to Shop",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Shop ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Owner ID",
+                        "name": "ownerId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Shop or Owner not found",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "UserAuth": []
+                    }
+                ],
+                "description": "Remove Owner from Shop",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shops (Admin only)"
+                ],
+                "summary": "Remove Owner from Shop",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Shop ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Owner ID",
+                        "name": "ownerId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Item not found",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/shops/{id}/status": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "UserAuth": []
+                    }
+                ],
+                "description": "Close a Shop right now regardless of its opening hours, or reopen it. Pre-orders for later days still follow the opening hours",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shop Schedule (Admin and Owner)"
+                ],
+                "summary": "Update Shop Open Status",
+                "parameters": [
+                    {
+                        "description": "Shop Status Payload",
+                        "name": "StatusPayload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ShopStatusRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Shop ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the shop as last fetched",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Item not found",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "Item was changed since it was fetched",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header is missing",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ginlib.Response"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "domain.BoardEntry": {
+            "type": "object",
+            "properties": {
+                "queue_number": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.BoardShop": {
+            "type": "object",
+            "properties": {
+                "orders": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.BoardEntry"
+                    }
+                },
+                "shop_id": {
+                    "type": "string"
+                },
+                "shop_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ImageVariants": {
+            "type": "object",
+            "properties": {
+                "medium": {
+                    "type": "string"
+                },
+                "medium_webp": {
+                    "type": "string"
+                },
+                "original": {
+                    "type": "string"
+                },
+                "thumbnail": {
+                    "type": "string"
+                },
+                "thumbnail_webp": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.Menu": {
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "daily_stock": {
+                    "type": "integer"
+                },
+                "menu_id": {
+                    "type": "string"
+                },
+                "menu_name": {
+                    "type": "string"
+                },
+                "menu_photo_link": {
+                    "type": "string"
+                },
+                "menu_photos": {
+                    "$ref": "#/definitions/domain.ImageVariants"
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.MenuOptionGroup"
+                    }
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "shop_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "stock_remaining": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.MenuCategory": {
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "type": "string"
+                },
+                "category_name": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "display_order": {
+                    "type": "integer"
+                },
+                "shop_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.MenuOptionGroup": {
+            "type": "object",
+            "properties": {
+                "display_order": {
+                    "type": "integer"
+                },
+                "group_name": {
+                    "type": "string"
+                },
+                "is_required": {
+                    "type": "boolean"
+                },
+                "max_select": {
+                    "type": "integer"
+                },
+                "min_select": {
+                    "type": "integer"
+                },
+                "option_group_id": {
+                    "type": "string"
+                },
+                "values": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.MenuOptionValue"
+                    }
+                }
+            }
+        },
+        "domain.MenuOptionValue": {
+            "type": "object",
+            "properties": {
+                "display_order": {
+                    "type": "integer"
+                },
+                "is_available": {
+                    "type": "boolean"
+                },
+                "option_value_id": {
+                    "type": "string"
+                },
+                "price_delta": {
+                    "type": "integer"
+                },
+                "value_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.Order": {
+            "type": "object",
+            "properties": {
+                "cancel_reason": {
+                    "type": "string"
+                },
+                "cancelled_at": {
+                    "type": "string"
+                },
+                "cancelled_by": {
+                    "type": "string"
+                },
+                "cancelled_by_type": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.OrderItem"
+                    }
+                },
+                "order_id": {
+                    "type": "string"
+                },
+                "payment_method": {
+                    "type": "string"
+                },
+                "payment_note": {
+                    "type": "string"
+                },
+                "payment_proof_link": {
+                    "type": "string"
+                },
+                "payment_status": {
+                    "type": "string"
+                },
+                "pickup_at": {
+                    "type": "string"
+                },
+                "queue_date": {
+                    "type": "string"
+                },
+                "queue_number": {
+                    "type": "integer"
+                },
+                "shop_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.OrderItem": {
+            "type": "object",
+            "properties": {
+                "menu_id": {
+                    "type": "string"
+                },
+                "menu_name": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "options": {
+                    "description": "UnitPrice already includes the price deltas of the chosen options",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.OrderItemOption"
+                    }
+                },
+                "order_item_id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "subtotal": {
+                    "type": "integer"
+                },
+                "unit_price": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.OrderItemOption": {
+            "type": "object",
+            "properties": {
+                "group_name": {
+                    "type": "string"
+                },
+                "option_value_id": {
+                    "type": "string"
+                },
+                "price_delta": {
+                    "type": "integer"
+                },
+                "value_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.OrderStatusHistory": {
+            "type": "object",
+            "properties": {
+                "actor_id": {
+                    "type": "string"
+                },
+                "actor_type": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "history_id": {
+                    "type": "string"
+                },
+                "new_status": {
+                    "type": "string"
+                },
+                "old_status": {
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.Owner": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "fullname": {
+                    "type": "string"
+                },
+                "owner_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                },
+                "wa_number": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.PickupSlot": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.PickupSlotItem"
                     }
+                },
+                "orders": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Order"
+                    }
+                },
+                "slot_end": {
+                    "type": "string"
+                },
+                "slot_start": {
+                    "type": "string"
                 }
             }
-        }
-    },
-    "definitions": {
-        "domain.Menu": {
+        },
+        "domain.PickupSlotItem": {
+            "type": "object",
+            "properties": {
+                "menu_id": {
+                    "type": "string"
+                },
+                "menu_name": {
+                    "type": "string"
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "quantity": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.SearchMenuHit": {
             "type": "object",
             "properties": {
+                "category_id": {
+                    "type": "string"
+                },
                 "created_at": {
                     "type": "string"
                 },
+                "daily_stock": {
+                    "type": "integer"
+                },
                 "menu_id": {
                     "type": "string"
                 },
@@ -1227,100 +3549,347 @@ const docTemplate = `{
                 "menu_photo_link": {
                     "type": "string"
                 },
+                "menu_photos": {
+                    "$ref": "#/definitions/domain.ImageVariants"
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.MenuOptionGroup"
+                    }
+                },
                 "price": {
                     "type": "integer"
                 },
+                "score": {
+                    "type": "number"
+                },
                 "shop_id": {
                     "type": "string"
                 },
                 "status": {
                     "type": "string"
                 },
+                "stock_remaining": {
+                    "type": "integer"
+                },
                 "updated_at": {
                     "type": "string"
+                },
+                "version": {
+                    "type": "integer"
                 }
             }
         },
-        "domain.Order": {
+        "domain.SearchResult": {
             "type": "object",
             "properties": {
-                "created_at": {
+                "menus": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.SearchMenuHit"
+                    }
+                },
+                "score": {
+                    "type": "number"
+                },
+                "shop": {
+                    "$ref": "#/definitions/domain.Shop"
+                }
+            }
+        },
+        "domain.Shop": {
+            "type": "object",
+            "properties": {
+                "closed_reason": {
                     "type": "string"
                 },
-                "menu_id": {
+                "closures": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ShopClosure"
+                    }
+                },
+                "created_at": {
                     "type": "string"
                 },
-                "order_id": {
+                "is_open": {
+                    "type": "boolean"
+                },
+                "manually_closed": {
+                    "type": "boolean"
+                },
+                "opening_hours": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ShopHours"
+                    }
+                },
+                "pickup_slot_capacity": {
+                    "type": "integer"
+                },
+                "shop_description": {
                     "type": "string"
                 },
-                "payment_method": {
+                "shop_id": {
                     "type": "string"
                 },
-                "payment_proof_link": {
+                "shop_name": {
                     "type": "string"
                 },
-                "status": {
+                "shop_photo_link": {
                     "type": "string"
                 },
+                "shop_photos": {
+                    "$ref": "#/definitions/domain.ImageVariants"
+                },
                 "updated_at": {
                     "type": "string"
                 },
-                "user_id": {
-                    "type": "string"
+                "version": {
+                    "type": "integer"
                 }
             }
         },
-        "domain.Owner": {
+        "domain.ShopClosure": {
             "type": "object",
             "properties": {
-                "created_at": {
+                "closure_id": {
                     "type": "string"
                 },
-                "fullname": {
+                "date": {
                     "type": "string"
                 },
-                "owner_id": {
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ShopHours": {
+            "type": "object",
+            "properties": {
+                "close_time": {
                     "type": "string"
                 },
-                "updated_at": {
+                "open_time": {
                     "type": "string"
                 },
-                "username": {
+                "weekday": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.KitchenTicket": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
                     "type": "string"
                 },
-                "wa_number": {
+                "ticket": {
                     "type": "string"
                 }
             }
         },
-        "domain.Shop": {
+        "dto.MenuCategoryRequest": {
             "type": "object",
+            "required": [
+                "category_name"
+            ],
             "properties": {
-                "created_at": {
+                "category_name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "display_order": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "dto.MenuOptionGroupRequest": {
+            "type": "object",
+            "required": [
+                "group_name",
+                "max_select",
+                "values"
+            ],
+            "properties": {
+                "group_name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "is_required": {
+                    "type": "boolean"
+                },
+                "max_select": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "min_select": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "values": {
+                    "type": "array",
+                    "maxItems": 30,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/dto.MenuOptionValueRequest"
+                    }
+                }
+            }
+        },
+        "dto.MenuOptionValueRequest": {
+            "type": "object",
+            "required": [
+                "value_name"
+            ],
+            "properties": {
+                "is_available": {
+                    "description": "Available defaults to true when left out",
+                    "type": "boolean"
+                },
+                "price_delta": {
+                    "type": "integer"
+                },
+                "value_name": {
+                    "type": "string",
+                    "maxLength": 100
+                }
+            }
+        },
+        "dto.MenuOptionsRequest": {
+            "type": "object",
+            "properties": {
+                "groups": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "$ref": "#/definitions/dto.MenuOptionGroupRequest"
+                    }
+                }
+            }
+        },
+        "dto.MenuRequest": {
+            "type": "object",
+            "required": [
+                "menu_name",
+                "menu_price",
+                "menu_status",
+                "shop_id"
+            ],
+            "properties": {
+                "category_id": {
+                    "description": "CategoryID is optional, an empty value removes the menu from its category",
                     "type": "string"
                 },
-                "shop_description": {
+                "daily_stock": {
+                    "description": "DailyStock turns on stock tracking, leaving it out turns it off. Stock\noverrides what is left for today and defaults to the daily stock.",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "menu_name": {
                     "type": "string"
                 },
-                "shop_id": {
+                "menu_price": {
+                    "type": "integer"
+                },
+                "menu_status": {
                     "type": "string"
                 },
-                "shop_name": {
+                "shop_id": {
                     "type": "string"
                 },
-                "shop_photo_link": {
+                "stock_remaining": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "dto.OrderCancelRequest": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "dto.OrderItemRequest": {
+            "type": "object",
+            "required": [
+                "menu_id",
+                "quantity"
+            ],
+            "properties": {
+                "menu_id": {
                     "type": "string"
                 },
-                "updated_at": {
+                "notes": {
                     "type": "string"
+                },
+                "options": {
+                    "description": "Options holds the ids of the chosen option values",
+                    "type": "array",
+                    "maxItems": 50,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "quantity": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 1
                 }
             }
         },
-        "dto.MenuRequest": {
-            "type": "object"
-        },
         "dto.OrderRequest": {
-            "type": "object"
+            "type": "object",
+            "required": [
+                "items",
+                "payment_method"
+            ],
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/dto.OrderItemRequest"
+                    }
+                },
+                "payment_method": {
+                    "type": "string",
+                    "enum": [
+                        "COD",
+                        "QRIS"
+                    ]
+                },
+                "pickup_at": {
+                    "description": "PickupAt requests a pickup slot, it must be the start of a slot",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.OrderUpdateRequest": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "payment_method": {
+                    "type": "string",
+                    "enum": [
+                        "COD",
+                        "QRIS"
+                    ]
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
         },
         "dto.OwnerRequest": {
             "type": "object",
@@ -1345,8 +3914,109 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.Pagination": {
+            "type": "object",
+            "properties": {
+                "has_more": {
+                    "type": "boolean"
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "next_cursor": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.PaymentVerificationRequest": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ShopClosureRequest": {
+            "type": "object",
+            "required": [
+                "date"
+            ],
+            "properties": {
+                "date": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "dto.ShopHoursItem": {
+            "type": "object",
+            "required": [
+                "close_time",
+                "open_time",
+                "weekday"
+            ],
+            "properties": {
+                "close_time": {
+                    "type": "string"
+                },
+                "open_time": {
+                    "type": "string"
+                },
+                "weekday": {
+                    "type": "integer",
+                    "maximum": 6,
+                    "minimum": 0
+                }
+            }
+        },
+        "dto.ShopHoursRequest": {
+            "type": "object",
+            "properties": {
+                "hours": {
+                    "type": "array",
+                    "maxItems": 50,
+                    "items": {
+                        "$ref": "#/definitions/dto.ShopHoursItem"
+                    }
+                }
+            }
+        },
         "dto.ShopRequest": {
-            "type": "object"
+            "type": "object",
+            "required": [
+                "shop_description",
+                "shop_name"
+            ],
+            "properties": {
+                "pickup_slot_capacity": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "shop_description": {
+                    "type": "string"
+                },
+                "shop_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ShopStatusRequest": {
+            "type": "object",
+            "required": [
+                "closed"
+            ],
+            "properties": {
+                "closed": {
+                    "type": "boolean"
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
         },
         "ginlib.Response": {
             "type": "object",
@@ -1361,6 +4031,9 @@ const docTemplate = `{
                 "message": {
                     "type": "string"
                 },
+                "pagination": {
+                    "$ref": "#/definitions/dto.Pagination"
+                },
                 "status": {
                     "type": "string"
                 }
@@ -0,0 +1,21 @@
+package domain
+
+const BoardEventUpdated = "board.updated"
+
+// BoardShop is what the public queue display shows for one stall. It
+// deliberately carries nothing but queue numbers and statuses, so no user,
+// item or payment detail of an order ever reaches the board.
+type BoardShop struct {
+	ShopID   string       `json:"shop_id" db:"shop_id"`
+	ShopName string       `json:"shop_name" db:"shop_name"`
+	Orders   []BoardEntry `json:"orders" db:"-"`
+}
+
+type BoardEntry struct {
+	QueueNumber int64  `json:"queue_number" db:"queue_number"`
+	Status      string `json:"status" db:"status"`
+}
+
+// BoardStatuses are the statuses shown on the board, from queued in the
+// kitchen up to waiting at the counter.
+var BoardStatuses = []string{OrderStatusAccepted, OrderStatusPreparing, OrderStatusReady}
@@ -7,6 +7,20 @@ var (
 	ErrBadRequest     = errors.New("bad data request")
 	ErrDuplicateEntry = errors.New("duplicate item entry")
 	ErrInvalidToken   = errors.New("invalid token")
+	ErrForbidden      = errors.New("forbidden to access this resource")
+
+	ErrInvalidStatusTransition = errors.New("invalid order status transition")
+	ErrInvalidPaymentState     = errors.New("payment is not in a verifiable state")
+	ErrOutOfStock              = errors.New("menu is out of stock")
+	ErrShopClosed              = errors.New("shop is closed")
+	ErrSlotFull                = errors.New("pickup slot is full")
+	ErrFileTooLarge            = errors.New("uploaded file is too large")
+	ErrUnsupportedFile         = errors.New("unsupported uploaded file type")
+	ErrIdempotencyInProgress   = errors.New("a request with this idempotency key is still in progress")
+	ErrIdempotencyKeyReused    = errors.New("idempotency key was already used for a different request")
+	ErrTooManyRequests         = errors.New("too many requests, try again later")
+	ErrPreconditionFailed      = errors.New("item was changed since it was fetched")
+	ErrPreconditionRequired    = errors.New("If-Match header is required")
 )
 
 func GetStatus(err error) (int, string) {
@@ -19,8 +33,23 @@ func GetStatus(err error) (int, string) {
 		return 404, "fail"
 	case ErrBadRequest, ErrInvalidToken:
 		return 400, "fail"
-	case ErrDuplicateEntry:
+	case ErrForbidden:
+		return 403, "fail"
+	case ErrDuplicateEntry, ErrInvalidStatusTransition, ErrInvalidPaymentState, ErrOutOfStock, ErrShopClosed, ErrSlotFull,
+		ErrIdempotencyInProgress:
 		return 409, "fail"
+	case ErrPreconditionFailed:
+		return 412, "fail"
+	case ErrFileTooLarge:
+		return 413, "fail"
+	case ErrUnsupportedFile:
+		return 415, "fail"
+	case ErrIdempotencyKeyReused:
+		return 422, "fail"
+	case ErrPreconditionRequired:
+		return 428, "fail"
+	case ErrTooManyRequests:
+		return 429, "fail"
 	default:
 		return 500, "error"
 	}
@@ -0,0 +1,11 @@
+package domain
+
+// ImageVariants holds the urls of every stored size of a photo, variants are
+// empty for photos saved before resizing was introduced.
+type ImageVariants struct {
+	Original      string `json:"original"`
+	Thumbnail     string `json:"thumbnail,omitempty"`
+	ThumbnailWebP string `json:"thumbnail_webp,omitempty"`
+	Medium        string `json:"medium,omitempty"`
+	MediumWebP    string `json:"medium_webp,omitempty"`
+}
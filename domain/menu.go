@@ -2,13 +2,25 @@ package domain
 
 import "time"
 
+const (
+	MenuStatusAvailable = "Ada"
+	MenuStatusSoldOut   = "Habis"
+)
+
 type Menu struct {
-	ID        string    `json:"menu_id" db:"menu_id"`
-	Name      string    `json:"menu_name" db:"menu_name"`
-	ShopID    string    `json:"shop_id" db:"menu_shop_id"`
-	Price     int64     `json:"price" db:"menu_price"`
-	Status    string    `json:"status" db:"menu_status"`
-	PhotoLink string    `json:"menu_photo_link" db:"menu_photo_link"`
-	CreatedAt time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+	ID         string            `json:"menu_id" db:"menu_id"`
+	Name       string            `json:"menu_name" db:"menu_name"`
+	ShopID     string            `json:"shop_id" db:"menu_shop_id"`
+	CategoryID string            `json:"category_id,omitempty" db:"menu_category_id"`
+	Price      int64             `json:"price" db:"menu_price"`
+	Status     string            `json:"status" db:"menu_status"`
+	DailyStock *int64            `json:"daily_stock" db:"daily_stock"`
+	Stock      *int64            `json:"stock_remaining" db:"stock_remaining"`
+	PhotoLink  string            `json:"menu_photo_link" db:"menu_photo_link"`
+	Photos     *ImageVariants    `json:"menu_photos,omitempty" db:"-"`
+	Options    []MenuOptionGroup `json:"options,omitempty" db:"-"`
+	Sold       int64             `json:"-" db:"sold"`
+	Version    int64             `json:"version" db:"version"`
+	CreatedAt  time.Time         `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time         `json:"updated_at" db:"updated_at"`
 }
@@ -0,0 +1,19 @@
+package domain
+
+import "time"
+
+type MenuCategory struct {
+	ID           string    `json:"category_id" db:"category_id"`
+	ShopID       string    `json:"shop_id" db:"shop_id"`
+	Name         string    `json:"category_name" db:"category_name"`
+	DisplayOrder int       `json:"display_order" db:"display_order"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// MenuSection is one category of a shop menu with its items, menus without a
+// category are returned in a last section with a nil Category.
+type MenuSection struct {
+	Category *MenuCategory `json:"category"`
+	Menus    []Menu        `json:"menus"`
+}
@@ -0,0 +1,42 @@
+package domain
+
+// MenuOptionGroup is a choice a student makes when ordering a menu, like the
+// spice level or extra toppings. A required group needs at least one value.
+type MenuOptionGroup struct {
+	ID           string            `json:"option_group_id" db:"option_group_id"`
+	MenuID       string            `json:"-" db:"menu_id"`
+	Name         string            `json:"group_name" db:"group_name"`
+	MinSelect    int               `json:"min_select" db:"min_select"`
+	MaxSelect    int               `json:"max_select" db:"max_select"`
+	Required     bool              `json:"is_required" db:"is_required"`
+	DisplayOrder int               `json:"display_order" db:"display_order"`
+	Values       []MenuOptionValue `json:"values" db:"-"`
+}
+
+type MenuOptionValue struct {
+	ID           string `json:"option_value_id" db:"option_value_id"`
+	GroupID      string `json:"-" db:"option_group_id"`
+	Name         string `json:"value_name" db:"value_name"`
+	PriceDelta   int64  `json:"price_delta" db:"price_delta"`
+	Available    bool   `json:"is_available" db:"is_available"`
+	DisplayOrder int    `json:"display_order" db:"display_order"`
+}
+
+// OrderItemOption is the snapshot of an option chosen for an order item.
+type OrderItemOption struct {
+	ID          string `json:"-" db:"order_item_option_id"`
+	OrderItemID string `json:"-" db:"order_item_id"`
+	ValueID     string `json:"option_value_id" db:"option_value_id"`
+	GroupName   string `json:"group_name" db:"group_name"`
+	ValueName   string `json:"value_name" db:"value_name"`
+	PriceDelta  int64  `json:"price_delta" db:"price_delta"`
+}
+
+// MinSelection is the least number of values a student has to pick.
+func (g *MenuOptionGroup) MinSelection() int {
+	if g.Required && g.MinSelect < 1 {
+		return 1
+	}
+
+	return g.MinSelect
+}
@@ -1,12 +1,109 @@
 package domain
 
+import (
+	"slices"
+	"time"
+)
+
+const (
+	OrderStatusWaiting   = "Waiting"
+	OrderStatusAccepted  = "Accepted"
+	OrderStatusPreparing = "Preparing"
+	OrderStatusReady     = "Ready"
+	OrderStatusPickedUp  = "PickedUp"
+	OrderStatusCancelled = "Cancelled"
+	OrderStatusRejected  = "Rejected"
+)
+
+const (
+	PaymentMethodCOD  = "COD"
+	PaymentMethodQRIS = "QRIS"
+)
+
+const (
+	PaymentStatusUnpaid    = "Unpaid"
+	PaymentStatusSubmitted = "Submitted"
+	PaymentStatusApproved  = "Approved"
+	PaymentStatusRejected  = "Rejected"
+)
+
+// orderStatusTransitions lists, for every non-final status, the statuses an
+// order is allowed to move to next. PickedUp, Cancelled and Rejected are final.
+var orderStatusTransitions = map[string][]string{
+	OrderStatusWaiting:   {OrderStatusAccepted, OrderStatusRejected, OrderStatusCancelled},
+	OrderStatusAccepted:  {OrderStatusPreparing, OrderStatusCancelled},
+	OrderStatusPreparing: {OrderStatusReady, OrderStatusCancelled},
+	OrderStatusReady:     {OrderStatusPickedUp, OrderStatusCancelled},
+}
+
 type Order struct {
-	ID               string `json:"order_id" db:"order_id"`
-	UserID           string `json:"user_id" db:"order_user_id"`
-	MenuID           string `json:"menu_id" db:"order_menu_id"`
-	Status           string `json:"status" db:"status"`
-	PaymentMethod    string `json:"payment_method" db:"payment_method"`
-	PaymentProofLink string `json:"payment_proof_link" db:"payment_proof_link"`
-	CreatedAt        string `json:"created_at" db:"created_at"`
-	UpdatedAt        string `json:"updated_at" db:"updated_at"`
+	ID               string      `json:"order_id" db:"order_id"`
+	UserID           string      `json:"user_id" db:"order_user_id"`
+	ShopID           string      `json:"shop_id" db:"order_shop_id"`
+	QueueNumber      int64       `json:"queue_number" db:"queue_number"`
+	QueueDate        string      `json:"queue_date" db:"queue_date"`
+	Status           string      `json:"status" db:"status"`
+	PaymentMethod    string      `json:"payment_method" db:"payment_method"`
+	PaymentProofLink string      `json:"payment_proof_link" db:"payment_proof_link"`
+	PaymentStatus    string      `json:"payment_status" db:"payment_status"`
+	PaymentNote      string      `json:"payment_note" db:"payment_note"`
+	Total            int64       `json:"total" db:"total"`
+	PickupAt         *time.Time  `json:"pickup_at" db:"pickup_at"`
+	CancelledAt      *time.Time  `json:"cancelled_at" db:"cancelled_at"`
+	CancelledBy      string      `json:"cancelled_by" db:"cancelled_by"`
+	CancelledByType  string      `json:"cancelled_by_type" db:"cancelled_by_type"`
+	CancelReason     string      `json:"cancel_reason" db:"cancel_reason"`
+	Items            []OrderItem `json:"items,omitempty" db:"-"`
+	Version          int64       `json:"version" db:"version"`
+	CreatedAt        string      `json:"created_at" db:"created_at"`
+	UpdatedAt        string      `json:"updated_at" db:"updated_at"`
+}
+
+type OrderItem struct {
+	ID        string `json:"order_item_id" db:"order_item_id"`
+	OrderID   string `json:"-" db:"order_id"`
+	MenuID    string `json:"menu_id" db:"menu_id"`
+	MenuName  string `json:"menu_name" db:"menu_name"`
+	Quantity  int64  `json:"quantity" db:"quantity"`
+	UnitPrice int64  `json:"unit_price" db:"unit_price"`
+	Subtotal  int64  `json:"subtotal" db:"subtotal"`
+	Notes     string `json:"notes" db:"notes"`
+	// UnitPrice already includes the price deltas of the chosen options
+	Options []OrderItemOption `json:"options,omitempty" db:"-"`
+}
+
+type OrderStatusHistory struct {
+	ID        string    `json:"history_id" db:"history_id"`
+	OrderID   string    `json:"order_id" db:"order_id"`
+	ActorID   string    `json:"actor_id" db:"actor_id"`
+	ActorType string    `json:"actor_type" db:"actor_type"`
+	OldStatus string    `json:"old_status" db:"old_status"`
+	NewStatus string    `json:"new_status" db:"new_status"`
+	Reason    string    `json:"reason" db:"reason"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+func IsOrderStatus(status string) bool {
+	switch status {
+	case OrderStatusWaiting, OrderStatusAccepted, OrderStatusPreparing, OrderStatusReady,
+		OrderStatusPickedUp, OrderStatusCancelled, OrderStatusRejected:
+		return true
+	default:
+		return false
+	}
+}
+
+func CanTransitionOrder(from, to string) bool {
+	return slices.Contains(orderStatusTransitions[from], to)
+}
+
+// OrderHoldsStock tells whether an order in the status has taken its items
+// out of the menu stock, which happens once it is accepted.
+func OrderHoldsStock(status string) bool {
+	switch status {
+	case OrderStatusAccepted, OrderStatusPreparing, OrderStatusReady:
+		return true
+	default:
+		return false
+	}
 }
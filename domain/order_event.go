@@ -0,0 +1,7 @@
+package domain
+
+const (
+	OrderEventCreated        = "order.created"
+	OrderEventStatusChanged  = "order.status_changed"
+	OrderEventPaymentUpdated = "order.payment_updated"
+)
@@ -8,6 +8,7 @@ type Owner struct {
 	WANumber  string    `json:"wa_number" db:"wa_number"`
 	Username  string    `json:"username" db:"username"`
 	Password  string    `json:"-" db:"password"`
+	Version   int64     `json:"version" db:"version"`
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
 }
@@ -0,0 +1,19 @@
+package domain
+
+import "time"
+
+// PickupSlot groups the orders a kitchen has to hand out in one slot, with
+// the quantities to prepare per menu and option combination.
+type PickupSlot struct {
+	Start  time.Time        `json:"slot_start"`
+	End    time.Time        `json:"slot_end"`
+	Items  []PickupSlotItem `json:"items"`
+	Orders []Order          `json:"orders"`
+}
+
+type PickupSlotItem struct {
+	MenuID   string   `json:"menu_id"`
+	MenuName string   `json:"menu_name"`
+	Options  []string `json:"options"`
+	Quantity int64    `json:"quantity"`
+}
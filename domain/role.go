@@ -1,5 +1,10 @@
 package domain
 
+const (
+	RoleAdmin = "Admin"
+	RoleOwner = "Owner"
+)
+
 type Role struct {
 	ID   string `db:"role_id"`
 	Name string `db:"role_name"`
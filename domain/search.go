@@ -0,0 +1,19 @@
+package domain
+
+type SearchMenuHit struct {
+	Menu
+	Score float64 `json:"score" db:"score"`
+}
+
+type SearchShopHit struct {
+	Shop
+	Score float64 `json:"score" db:"score"`
+}
+
+// SearchResult groups the matching menus of one shop, Score is the best score
+// of the shop itself or any of its menus.
+type SearchResult struct {
+	Shop  Shop            `json:"shop"`
+	Score float64         `json:"score"`
+	Menus []SearchMenuHit `json:"menus"`
+}
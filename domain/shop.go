@@ -3,10 +3,18 @@ package domain
 import "time"
 
 type Shop struct {
-	ID          string    `json:"shop_id" db:"shop_id"`
-	Name        string    `json:"shop_name" db:"shop_name"`
-	Description string    `json:"shop_description" db:"shop_description"`
-	PhotoLink   string    `json:"shop_photo_link" db:"shop_photo_link"`
-	CreatedAt   time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+	ID             string         `json:"shop_id" db:"shop_id"`
+	Name           string         `json:"shop_name" db:"shop_name"`
+	Description    string         `json:"shop_description" db:"shop_description"`
+	PhotoLink      string         `json:"shop_photo_link" db:"shop_photo_link"`
+	Photos         *ImageVariants `json:"shop_photos,omitempty" db:"-"`
+	IsOpen         bool           `json:"is_open" db:"-"`
+	ManuallyClosed bool           `json:"manually_closed" db:"manually_closed"`
+	ClosedReason   string         `json:"closed_reason" db:"closed_reason"`
+	SlotCapacity   *int           `json:"pickup_slot_capacity" db:"pickup_slot_capacity"`
+	Version        int64          `json:"version" db:"version"`
+	Hours          []ShopHours    `json:"opening_hours,omitempty" db:"-"`
+	Closures       []ShopClosure  `json:"closures,omitempty" db:"-"`
+	CreatedAt      time.Time      `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at" db:"updated_at"`
 }
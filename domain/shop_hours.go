@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+const (
+	DateLayout      = "2006-01-02"
+	TimeOfDayLayout = "15:04"
+)
+
+// ShopHours is one opening interval of a shop on a weekday, times are HH:MM
+// in the canteen timezone.
+type ShopHours struct {
+	ID        string `json:"-" db:"shop_hour_id"`
+	ShopID    string `json:"-" db:"shop_id"`
+	Weekday   int    `json:"weekday" db:"weekday"`
+	OpenTime  string `json:"open_time" db:"open_time"`
+	CloseTime string `json:"close_time" db:"close_time"`
+}
+
+// ShopClosure closes a shop for a whole day, like a campus holiday.
+type ShopClosure struct {
+	ID     string `json:"closure_id" db:"closure_id"`
+	ShopID string `json:"-" db:"shop_id"`
+	Date   string `json:"date" db:"closed_on"`
+	Reason string `json:"reason" db:"reason"`
+}
+
+// OpenAt tells whether the shop takes orders at the given time, both times
+// must be in the canteen timezone. A shop without any opening hours is always
+// open unless it is closed for the day or closed manually. Closing manually
+// is meant for a break, so it only holds for the business day of now and
+// pre-orders for later days still follow the hours.
+func (s *Shop) OpenAt(hours []ShopHours, closures []ShopClosure, at, now time.Time) bool {
+	date := at.Format(DateLayout)
+
+	if s.ManuallyClosed && date == now.Format(DateLayout) {
+		return false
+	}
+
+	if slices.ContainsFunc(closures, func(closure ShopClosure) bool { return closure.Date == date }) {
+		return false
+	}
+
+	if len(hours) == 0 {
+		return true
+	}
+
+	clock := at.Format(TimeOfDayLayout)
+
+	for _, interval := range hours {
+		if interval.Weekday == int(at.Weekday()) && interval.OpenTime <= clock && clock < interval.CloseTime {
+			return true
+		}
+	}
+
+	return false
+}
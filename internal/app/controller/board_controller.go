@@ -0,0 +1,86 @@
+package controller
+
+import (
+	"github.com/devanfer02/filkom-canteen/domain"
+	"github.com/devanfer02/filkom-canteen/internal/app/service"
+	"github.com/devanfer02/filkom-canteen/internal/dto"
+	ginlib "github.com/devanfer02/filkom-canteen/internal/pkg/gin"
+	"github.com/gin-gonic/gin"
+)
+
+type boardController struct {
+	orderSvc service.IOrderService
+}
+
+// MountBoardRoutes serves the queue display in the canteen, it only needs
+// the api key so a TV never has to hold a user token.
+func MountBoardRoutes(r *gin.RouterGroup, orderSvc service.IOrderService) {
+	boardCtr := &boardController{orderSvc}
+	boardR := r.Group("/board")
+
+	boardR.GET("", boardCtr.FetchBoard)
+	boardR.GET("/events", boardCtr.StreamBoard)
+}
+
+// @Tags			Queue Board
+// @Summary		Fetch Queue Board
+// @Description	Fetch the queue numbers and statuses of today's Accepted, Preparing and Ready orders per Shop. No other order detail is exposed
+// @Produce		json
+// @Param			shop_id	query		string										false	"Shop ID"
+// @Success		200		{object}	ginlib.Response{data=[]domain.BoardShop}	"OK"
+// @Failure		400		{object}	ginlib.Response								"Bad Request"
+// @Failure		404		{object}	ginlib.Response								"Shop not found"
+// @Failure		500		{object}	ginlib.Response								"Internal Server Error"
+// @Security		ApiKeyAuth
+// @Router			/api/v1/board [get]
+func (c *boardController) FetchBoard(ctx *gin.Context) {
+	var (
+		code    = 500
+		status  = "fail"
+		message = "failed to fetch queue board"
+		board   []domain.BoardShop
+		err     error
+	)
+
+	defer func() {
+		ginlib.SendResponse(ctx, code, status, message, board, err)
+	}()
+
+	board, err = c.orderSvc.FetchBoard(&dto.OrderParams{
+		ShopID: ctx.Query("shop_id"),
+	})
+	code, status = domain.GetStatus(err)
+
+	if err != nil {
+		return
+	}
+
+	message = "successfully fetch queue board"
+}
+
+// @Tags			Queue Board
+// @Summary		Stream Queue Board
+// @Description	Stream the queue board as Server-Sent Events. The stream starts with one board.updated event per Shop and sends the full board of a Shop again every time it changes
+// @Produce		text/event-stream
+// @Param			shop_id	query		string			false	"Shop ID"
+// @Success		200		{string}	string			"Event stream"
+// @Failure		400		{object}	ginlib.Response	"Bad Request"
+// @Failure		404		{object}	ginlib.Response	"Shop not found"
+// @Failure		500		{object}	ginlib.Response	"Internal Server Error"
+// @Security		ApiKeyAuth
+// @Router			/api/v1/board/events [get]
+func (c *boardController) StreamBoard(ctx *gin.Context) {
+	stream, unsubscribe, err := c.orderSvc.SubscribeBoard(&dto.OrderParams{
+		ShopID: ctx.Query("shop_id"),
+	})
+
+	if err != nil {
+		code, status := domain.GetStatus(err)
+		ginlib.SendResponse(ctx, code, status, "failed to subscribe to queue board", nil, err)
+		return
+	}
+
+	defer unsubscribe()
+
+	ginlib.SendEventStream(ctx, stream)
+}
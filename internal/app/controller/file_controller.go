@@ -0,0 +1,61 @@
+package controller
+
+import (
+	"strings"
+
+	"github.com/devanfer02/filkom-canteen/domain"
+	ginlib "github.com/devanfer02/filkom-canteen/internal/pkg/gin"
+	"github.com/devanfer02/filkom-canteen/internal/pkg/storage"
+	"github.com/gin-gonic/gin"
+)
+
+type fileController struct {
+	store storage.StorageInterface
+}
+
+// MountFileRoutes serves files kept by the local storage driver, object
+// storages such as MinIO serve their files themselves.
+func MountFileRoutes(r *gin.RouterGroup, store storage.StorageInterface) {
+	fileCtr := &fileController{store}
+	fileR := r.Group("/files")
+
+	fileR.GET("/*key", fileCtr.Serve)
+}
+
+func (c *fileController) Serve(ctx *gin.Context) {
+	var (
+		key    = strings.TrimPrefix(ctx.Param("key"), "/")
+		public = storage.IsPublic(key)
+	)
+
+	if !storage.IsValidKey(key) {
+		code, status := domain.GetStatus(domain.ErrBadRequest)
+		ginlib.SendAbortResponse(ctx, code, status, "invalid file key", domain.ErrBadRequest)
+		return
+	}
+
+	if !public && !storage.VerifySignature(key, ctx.Query("expires"), ctx.Query("signature")) {
+		code, status := domain.GetStatus(domain.ErrForbidden)
+		ginlib.SendAbortResponse(ctx, code, status, "invalid or expired file link", domain.ErrForbidden)
+		return
+	}
+
+	obj, err := c.store.Get(ctx.Request.Context(), key)
+
+	if err != nil {
+		code, status := domain.GetStatus(err)
+		ginlib.SendAbortResponse(ctx, code, status, "failed to fetch file", err)
+		return
+	}
+
+	defer obj.Body.Close()
+
+	if public {
+		ctx.Header("Cache-Control", "public, max-age=86400")
+	} else {
+		ctx.Header("Cache-Control", "private, no-store")
+	}
+
+	ctx.Header("X-Content-Type-Options", "nosniff")
+	ctx.DataFromReader(200, obj.Size, obj.ContentType, obj.Body, nil)
+}
@@ -0,0 +1,281 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/devanfer02/filkom-canteen/domain"
+	"github.com/devanfer02/filkom-canteen/internal/app/service"
+	"github.com/devanfer02/filkom-canteen/internal/dto"
+	"github.com/devanfer02/filkom-canteen/internal/infra/env"
+	"github.com/devanfer02/filkom-canteen/internal/middleware"
+	"github.com/devanfer02/filkom-canteen/internal/pkg/events"
+	ginlib "github.com/devanfer02/filkom-canteen/internal/pkg/gin"
+	"github.com/devanfer02/filkom-canteen/internal/pkg/ticket"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	kitchenWriteWait   = 10 * time.Second
+	kitchenPongWait    = 60 * time.Second
+	kitchenPingPeriod  = 50 * time.Second
+	kitchenMaxMessage  = 4096
+	kitchenCommandType = "command.result"
+)
+
+var kitchenUpgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	CheckOrigin:     checkKitchenOrigin,
+}
+
+type kitchenReply struct {
+	Type      string          `json:"type"`
+	RequestID string          `json:"request_id,omitempty"`
+	Data      ginlib.Response `json:"data"`
+}
+
+type kitchenController struct {
+	orderSvc service.IOrderService
+	tickets  ticket.TicketInterface
+}
+
+// MountKitchenRoutes mounts the ticket route on r and the WebSocket on ws,
+// which must not require headers a browser WebSocket cannot send.
+func MountKitchenRoutes(
+	r *gin.RouterGroup,
+	ws *gin.RouterGroup,
+	orderSvc service.IOrderService,
+	tickets ticket.TicketInterface,
+	mdlwr *middleware.Middleware,
+) {
+	kitchenCtr := &kitchenController{orderSvc, tickets}
+
+	r.POST("/orders/kitchen/ticket", mdlwr.Authenticate(), mdlwr.AuthorizeAdmin("Admin", "Owner"), kitchenCtr.IssueTicket)
+	ws.GET("/orders/kitchen", mdlwr.Ticket(), mdlwr.AuthorizeAdmin("Admin", "Owner"), kitchenCtr.Connect)
+}
+
+// @Tags			Orders (Admin and Owner)
+// @Summary		Issue Kitchen Ticket
+// @Description	Issue a one time ticket to open the kitchen channel with, it expires after 30 seconds
+// @Produce		json
+// @Success		200	{object}	ginlib.Response{data=dto.KitchenTicket}	"OK"
+// @Failure		500	{object}	ginlib.Response							"Internal Server Error"
+// @Security		ApiKeyAuth
+// @Security		UserAuth
+// @Router			/api/v1/orders/kitchen/ticket [post]
+func (c *kitchenController) IssueTicket(ctx *gin.Context) {
+	var (
+		code    = 500
+		status  = "error"
+		message = "failed to issue kitchen ticket"
+		issued  *dto.KitchenTicket
+		err     error
+	)
+
+	defer func() {
+		ginlib.SendResponse(ctx, code, status, message, issued, err)
+	}()
+
+	token, expiresAt, err := c.tickets.Issue(ctx.Request.Context(), &ticket.Ticket{
+		UserID: ctx.GetString("id"),
+		Issuer: ctx.GetString("user"),
+		Role:   ctx.GetString("role"),
+	})
+	code, status = domain.GetStatus(err)
+
+	if err != nil {
+		return
+	}
+
+	issued = &dto.KitchenTicket{Ticket: token, ExpiresAt: expiresAt}
+	message = "successfully issue kitchen ticket"
+}
+
+// @Tags			Orders (Admin and Owner)
+// @Summary		Kitchen Display Channel
+// @Description	Upgrade to a WebSocket, authenticated with a one time ticket and open to browsers from WS_ALLOWED_ORIGINS only, that pushes every order event of the Shop as {"type", "data"} messages, the same events as the order event stream. The tablet advances orders by sending {"request_id", "action": "update_status", "order_id", "version", "status", "reason", "payment_method"}, which goes through the same validation and ownership rules as updating an order over http, including the version check of If-Match, and is answered with a {"type": "command.result", "request_id", "data"} message holding the usual response body
+// @Param			shop_id	query		string			true	"Shop ID"
+// @Param			ticket	query		string			true	"Ticket from the kitchen ticket route"
+// @Success		101		{string}	string			"Switching Protocols"
+// @Failure		400		{object}	ginlib.Response	"Bad Request or invalid ticket"
+// @Failure		403		{object}	ginlib.Response	"Forbidden"
+// @Failure		500		{object}	ginlib.Response	"Internal Server Error"
+// @Router			/api/v1/orders/kitchen [get]
+func (c *kitchenController) Connect(ctx *gin.Context) {
+	actor := dto.OrderParams{
+		ActorID:   ctx.GetString("id"),
+		ActorType: ctx.GetString("user"),
+		ActorRole: ctx.GetString("role_name"),
+	}
+	shopID := ctx.Query("shop_id")
+
+	if shopID == "" {
+		code, status := domain.GetStatus(domain.ErrBadRequest)
+		ginlib.SendResponse(ctx, code, status, "failed to connect to kitchen channel", nil, domain.ErrBadRequest)
+		return
+	}
+
+	stream, unsubscribe, err := c.orderSvc.SubscribeOrderEvents(&dto.OrderParams{
+		ShopID:    shopID,
+		ActorID:   actor.ActorID,
+		ActorType: actor.ActorType,
+		ActorRole: actor.ActorRole,
+	})
+
+	if err != nil {
+		code, status := domain.GetStatus(err)
+		ginlib.SendResponse(ctx, code, status, "failed to connect to kitchen channel", nil, err)
+		return
+	}
+
+	defer unsubscribe()
+
+	// the upgrader already replied to the client when it fails
+	conn, err := kitchenUpgrader.Upgrade(ctx.Writer, ctx.Request, nil)
+
+	if err != nil {
+		return
+	}
+
+	defer conn.Close()
+
+	replies := make(chan kitchenReply, 8)
+	done := make(chan struct{})
+	// the gin context is reused once Connect returns, so the reader is told to
+	// stop through its own channel instead
+	quit := make(chan struct{})
+	defer close(quit)
+
+	go c.readCommands(conn, actor, replies, done, quit)
+
+	c.writeMessages(conn, stream, replies, done)
+}
+
+// writeMessages is the only writer of the connection, it ends once the
+// reader is gone or a write fails.
+func (c *kitchenController) writeMessages(
+	conn *websocket.Conn,
+	stream <-chan events.Event,
+	replies <-chan kitchenReply,
+	done <-chan struct{},
+) {
+	ping := time.NewTicker(kitchenPingPeriod)
+	defer ping.Stop()
+
+	for {
+		var message any
+
+		select {
+		case <-done:
+			return
+		case event, ok := <-stream:
+			if !ok {
+				return
+			}
+
+			message = event
+		case reply := <-replies:
+			message = reply
+		case <-ping.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(kitchenWriteWait)); err != nil {
+				return
+			}
+
+			continue
+		}
+
+		conn.SetWriteDeadline(time.Now().Add(kitchenWriteWait))
+
+		if err := conn.WriteJSON(message); err != nil {
+			return
+		}
+	}
+}
+
+func (c *kitchenController) readCommands(
+	conn *websocket.Conn,
+	actor dto.OrderParams,
+	replies chan<- kitchenReply,
+	done chan<- struct{},
+	quit <-chan struct{},
+) {
+	defer close(done)
+
+	conn.SetReadLimit(kitchenMaxMessage)
+	conn.SetReadDeadline(time.Now().Add(kitchenPongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(kitchenPongWait))
+	})
+
+	for {
+		_, payload, err := conn.ReadMessage()
+
+		if err != nil {
+			return
+		}
+
+		select {
+		case replies <- c.handleCommand(actor, payload):
+		case <-quit:
+			return
+		}
+	}
+}
+
+func (c *kitchenController) handleCommand(actor dto.OrderParams, payload []byte) kitchenReply {
+	var (
+		command dto.KitchenCommand
+		message = "failed to update order"
+		reply   = kitchenReply{Type: kitchenCommandType}
+		err     error
+	)
+
+	if err = json.Unmarshal(payload, &command); err == nil {
+		reply.RequestID = command.RequestID
+		err = binding.Validator.ValidateStruct(&command)
+	}
+
+	if err != nil {
+		err = domain.ErrBadRequest
+	} else {
+		actor.ID = command.OrderID
+		actor.Versions = []int64{command.Version}
+		err = c.orderSvc.UpdateOrder(&actor, &command.OrderUpdateRequest)
+	}
+
+	code, status := domain.GetStatus(err)
+
+	if err == nil {
+		message = "successfully update order"
+	}
+
+	reply.Data = ginlib.NewResponse(code, status, message, nil, nil, err)
+
+	return reply
+}
+
+// checkKitchenOrigin lets browsers connect only from WS_ALLOWED_ORIGINS or
+// the api's own host, clients without an Origin header are not browsers.
+func checkKitchenOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+
+	if origin == "" {
+		return true
+	}
+
+	for _, allowed := range strings.Split(env.AppEnv.WSOrigins, ",") {
+		if allowed = strings.TrimSpace(allowed); allowed != "" && strings.EqualFold(allowed, origin) {
+			return true
+		}
+	}
+
+	parsed, err := url.Parse(origin)
+
+	return err == nil && strings.EqualFold(parsed.Host, r.Host)
+}
@@ -0,0 +1,198 @@
+package controller
+
+import (
+	"github.com/devanfer02/filkom-canteen/domain"
+	"github.com/devanfer02/filkom-canteen/internal/app/service"
+	"github.com/devanfer02/filkom-canteen/internal/dto"
+	"github.com/devanfer02/filkom-canteen/internal/middleware"
+	ginlib "github.com/devanfer02/filkom-canteen/internal/pkg/gin"
+	"github.com/gin-gonic/gin"
+)
+
+type menuCategoryController struct {
+	categorySvc service.IMenuCategoryService
+}
+
+func MountMenuCategoryRoutes(r *gin.RouterGroup, categorySvc service.IMenuCategoryService, mdlwr *middleware.Middleware) {
+	categoryCtr := &menuCategoryController{categorySvc}
+	categoryR := r.Group("/shops/:id/categories")
+
+	categoryR.GET("", categoryCtr.FetchAll)
+	categoryR.POST("", mdlwr.Authenticate(), mdlwr.AuthorizeAdmin("Admin", "Owner"), categoryCtr.CreateCategory)
+	categoryR.PUT("/:categoryId", mdlwr.Authenticate(), mdlwr.AuthorizeAdmin("Admin", "Owner"), categoryCtr.UpdateCategory)
+	categoryR.DELETE("/:categoryId", mdlwr.Authenticate(), mdlwr.AuthorizeAdmin("Admin", "Owner"), categoryCtr.DeleteCategory)
+}
+
+// @Tags			Menu Categories
+// @Summary		Fetch Shop Menu Categories
+// @Description	Fetch Menu Categories of a Shop in Display Order
+// @Produce		json
+// @Param			id	path		string										true	"Shop ID"
+// @Success		200	{object}	ginlib.Response{data=[]domain.MenuCategory}	"OK"
+// @Failure		400	{object}	ginlib.Response								"Bad Request"
+// @Failure		500	{object}	ginlib.Response								"Internal Server Error"
+// @Security		ApiKeyAuth
+// @Router			/api/v1/shops/{id}/categories [get]
+func (c *menuCategoryController) FetchAll(ctx *gin.Context) {
+	var (
+		code       = 500
+		status     = "fail"
+		message    = "failed to fetch menu categories"
+		categories []domain.MenuCategory
+		err        error
+		idParam    = ctx.Param("id")
+	)
+
+	defer func() {
+		ginlib.SendResponse(ctx, code, status, message, categories, err)
+	}()
+
+	categories, err = c.categorySvc.FetchCategories(&dto.MenuCategoryParams{
+		ShopID: idParam,
+	})
+	code, status = domain.GetStatus(err)
+
+	if err != nil {
+		return
+	}
+
+	message = "successfully fetch menu categories"
+}
+
+// @Tags			Menu Categories (Admin and Owner)
+// @Summary		Create Menu Category
+// @Description	Create Menu Category for a Shop
+// @Produce		json
+// @Param			CategoryPayload	body		dto.MenuCategoryRequest	true	"Menu Category Payload"
+// @Param			id				path		string					true	"Shop ID"
+// @Success		200				{object}	ginlib.Response			"OK"
+// @Failure		400				{object}	ginlib.Response			"Bad Request"
+// @Failure		403				{object}	ginlib.Response			"Forbidden"
+// @Failure		409				{object}	ginlib.Response			"Category name already exists"
+// @Failure		500				{object}	ginlib.Response			"Internal Server Error"
+// @Security		ApiKeyAuth
+// @Security		UserAuth
+// @Router			/api/v1/shops/{id}/categories [post]
+func (c *menuCategoryController) CreateCategory(ctx *gin.Context) {
+	var (
+		code    = 400
+		status  = "fail"
+		message = "failed to create menu category"
+		req     dto.MenuCategoryRequest
+		err     error
+		idParam = ctx.Param("id")
+	)
+
+	defer func() {
+		ginlib.SendResponse(ctx, code, status, message, nil, err)
+	}()
+
+	if err = ctx.ShouldBindJSON(&req); err != nil {
+		code, status = domain.GetStatus(domain.ErrBadRequest)
+		return
+	}
+
+	err = c.categorySvc.CreateCategory(&dto.MenuCategoryParams{
+		ShopID:    idParam,
+		ActorID:   ctx.GetString("id"),
+		ActorRole: ctx.GetString("role_name"),
+	}, &req)
+	code, status = domain.GetStatus(err)
+
+	if err != nil {
+		return
+	}
+
+	message = "successfully create menu category"
+}
+
+// @Tags			Menu Categories (Admin and Owner)
+// @Summary		Update Menu Category
+// @Description	Rename or Reorder a Menu Category
+// @Produce		json
+// @Param			CategoryPayload	body		dto.MenuCategoryRequest	true	"Menu Category Payload"
+// @Param			id				path		string					true	"Shop ID"
+// @Param			categoryId		path		string					true	"Category ID"
+// @Success		200				{object}	ginlib.Response			"OK"
+// @Failure		400				{object}	ginlib.Response			"Bad Request"
+// @Failure		403				{object}	ginlib.Response			"Forbidden"
+// @Failure		404				{object}	ginlib.Response			"Item not found"
+// @Failure		409				{object}	ginlib.Response			"Category name already exists"
+// @Failure		500				{object}	ginlib.Response			"Internal Server Error"
+// @Security		ApiKeyAuth
+// @Security		UserAuth
+// @Router			/api/v1/shops/{id}/categories/{categoryId} [put]
+func (c *menuCategoryController) UpdateCategory(ctx *gin.Context) {
+	var (
+		code    = 400
+		status  = "fail"
+		message = "failed to update menu category"
+		req     dto.MenuCategoryRequest
+		err     error
+		idParam = ctx.Param("id")
+	)
+
+	defer func() {
+		ginlib.SendResponse(ctx, code, status, message, nil, err)
+	}()
+
+	if err = ctx.ShouldBindJSON(&req); err != nil {
+		code, status = domain.GetStatus(domain.ErrBadRequest)
+		return
+	}
+
+	err = c.categorySvc.UpdateCategory(&dto.MenuCategoryParams{
+		ID:        ctx.Param("categoryId"),
+		ShopID:    idParam,
+		ActorID:   ctx.GetString("id"),
+		ActorRole: ctx.GetString("role_name"),
+	}, &req)
+	code, status = domain.GetStatus(err)
+
+	if err != nil {
+		return
+	}
+
+	message = "successfully update menu category"
+}
+
+// @Tags			Menu Categories (Admin and Owner)
+// @Summary		Delete Menu Category
+// @Description	Delete a Menu Category, its menus become uncategorized
+// @Produce		json
+// @Param			id			path		string			true	"Shop ID"
+// @Param			categoryId	path		string			true	"Category ID"
+// @Success		200			{object}	ginlib.Response	"OK"
+// @Failure		403			{object}	ginlib.Response	"Forbidden"
+// @Failure		404			{object}	ginlib.Response	"Item not found"
+// @Failure		500			{object}	ginlib.Response	"Internal Server Error"
+// @Security		ApiKeyAuth
+// @Security		UserAuth
+// @Router			/api/v1/shops/{id}/categories/{categoryId} [delete]
+func (c *menuCategoryController) DeleteCategory(ctx *gin.Context) {
+	var (
+		code    = 400
+		status  = "fail"
+		message = "failed to delete menu category"
+		err     error
+		idParam = ctx.Param("id")
+	)
+
+	defer func() {
+		ginlib.SendResponse(ctx, code, status, message, nil, err)
+	}()
+
+	err = c.categorySvc.DeleteCategory(&dto.MenuCategoryParams{
+		ID:        ctx.Param("categoryId"),
+		ShopID:    idParam,
+		ActorID:   ctx.GetString("id"),
+		ActorRole: ctx.GetString("role_name"),
+	})
+	code, status = domain.GetStatus(err)
+
+	if err != nil {
+		return
+	}
+
+	message = "successfully delete menu category"
+}
@@ -18,38 +18,72 @@ func MountMenuRoutes(r *gin.RouterGroup, menuSvc service.IMenuService, mdlwr *mi
 	menuCtr := &menuController{menuSvc}
 	menuR := r.Group("/menus")
 
-	menuR.GET("", menuCtr.FetchAll)
-	menuR.GET("/:id", menuCtr.FetchByID)
+	menuR.GET("", mdlwr.CacheResponse(), menuCtr.FetchAll)
+	menuR.GET("/:id", mdlwr.CacheResponse(), menuCtr.FetchByID)
 	menuR.POST("", mdlwr.Authenticate(), mdlwr.AuthorizeAdmin("Admin", "Owner"), menuCtr.CreateMenu)
 	menuR.PUT("/:id", mdlwr.Authenticate(), mdlwr.AuthorizeAdmin("Admin", "Owner"), menuCtr.UpdateMenu)
+	menuR.PUT("/:id/options", mdlwr.Authenticate(), mdlwr.AuthorizeAdmin("Admin", "Owner"), menuCtr.UpdateMenuOptions)
 	menuR.DELETE("/:id", mdlwr.Authenticate(), mdlwr.AuthorizeAdmin("Admin", "Owner"), menuCtr.DeleteMenu)
 }
 
 // @Tags			Menus
 // @Summary		Fetch All Menus
-// @Description	Fetch All Menus From Database
+// @Description	Fetch All Menus From Database, with group=category and a shop_id the data is a list of domain.MenuSection instead
 // @Produce		json
-// @Param			shop_id	query		string								false	"Shop ID"
-// @Success		200		{object}	ginlib.Response{data=[]domain.Menu}	"OK"
-// @Failure		500		{object}	ginlib.Response						"Internal Server Error"
+// @Param			shop_id			query		string								false	"Shop ID"
+// @Param			min_price		query		int									false	"Minimum price"
+// @Param			max_price		query		int									false	"Maximum price"
+// @Param			status			query		string								false	"Menu status"	Enums(available, sold_out)
+// @Param			q				query		string								false	"Search menu name"
+// @Param			sort			query		string								false	"Sort order, defaults to newest"								Enums(newest, price_asc, price_desc, name_asc, name_desc, popular)
+// @Param			group			query		string								false	"Group a shop menu into category sections, requires shop_id"	Enums(category)
+// @Param			limit			query		int									false	"Page size, defaults to 20 and capped at 100"
+// @Param			cursor			query		string								false	"Cursor of the next page, also accepted as the X-Cursor header"
+// @Param			If-None-Match	header		string								false	"ETag of a previously fetched response"
+// @Success		200				{object}	ginlib.Response{data=[]domain.Menu}	"OK"
+// @Header			200				{string}	ETag								"Version of the response for If-None-Match"
+// @Success		304				"Not Modified, the cached response is still current"
+// @Failure		400				{object}	ginlib.Response	"Invalid filter, limit or cursor"
+// @Failure		500				{object}	ginlib.Response	"Internal Server Error"
 // @Security		ApiKeyAuth
 // @Router			/api/v1/menus [get]
 func (c *menuController) FetchAll(ctx *gin.Context) {
+	if ctx.Query("group") == "category" {
+		c.fetchSections(ctx)
+		return
+	}
+
 	var (
-		code    = 500
-		status  = "fail"
-		message = "failed to fetch all menus"
-		menus   []domain.Menu
-		err     error
-		shopId  = ctx.Query("shop_id")
+		code       = 500
+		status     = "fail"
+		message    = "failed to fetch all menus"
+		menus      []domain.Menu
+		pagination *dto.Pagination
+		filter     dto.MenuFilter
+		err        error
+		shopId     = ctx.Query("shop_id")
 	)
 
 	defer func() {
-		ginlib.SendResponse(ctx, code, status, message, menus, err)
+		ginlib.SendPaginatedResponse(ctx, code, status, message, menus, pagination, err)
 	}()
 
-	menus, err = c.menuSvc.FetchAllMenus(&dto.MenuParams{
+	page, err := ginlib.ParsePage(ctx)
+
+	if err != nil {
+		code, status = domain.GetStatus(err)
+		return
+	}
+
+	if err = ctx.ShouldBindQuery(&filter); err != nil {
+		code, status = domain.GetStatus(domain.ErrBadRequest)
+		return
+	}
+
+	menus, pagination, err = c.menuSvc.FetchAllMenus(&dto.MenuParams{
 		ShopID: shopId,
+		Page:   page,
+		Filter: filter,
 	})
 	code, status = domain.GetStatus(err)
 
@@ -60,14 +94,50 @@ func (c *menuController) FetchAll(ctx *gin.Context) {
 	message = "successfully fetch all menus"
 }
 
+func (c *menuController) fetchSections(ctx *gin.Context) {
+	var (
+		code     = 500
+		status   = "fail"
+		message  = "failed to fetch menu sections"
+		sections []domain.MenuSection
+		filter   dto.MenuFilter
+		err      error
+		shopId   = ctx.Query("shop_id")
+	)
+
+	defer func() {
+		ginlib.SendResponse(ctx, code, status, message, sections, err)
+	}()
+
+	if err = ctx.ShouldBindQuery(&filter); err != nil {
+		code, status = domain.GetStatus(domain.ErrBadRequest)
+		return
+	}
+
+	sections, err = c.menuSvc.FetchMenuSections(&dto.MenuParams{
+		ShopID: shopId,
+		Filter: filter,
+	})
+	code, status = domain.GetStatus(err)
+
+	if err != nil {
+		return
+	}
+
+	message = "successfully fetch menu sections"
+}
+
 // @Tags			Menus
 // @Summary		Fetch Menu By ID
 // @Description	Fetch Menu By ID From DB
 // @Produce		json
-// @Param			id	path		string								true	"Menu ID"
-// @Success		200	{object}	ginlib.Response{data=domain.Menu}	"OK"
-// @Failure		404	{object}	ginlib.Response						"Item not found"
-// @Failure		500	{object}	ginlib.Response						"Internal Server Error"
+// @Param			id				path		string								true	"Menu ID"
+// @Param			If-None-Match	header		string								false	"ETag of a previously fetched response"
+// @Success		200				{object}	ginlib.Response{data=domain.Menu}	"OK"
+// @Header			200				{string}	ETag								"Version of the item, for If-None-Match and for If-Match on updates"
+// @Success		304				"Not Modified, the cached response is still current"
+// @Failure		404				{object}	ginlib.Response	"Item not found"
+// @Failure		500				{object}	ginlib.Response	"Internal Server Error"
 // @Security		ApiKeyAuth
 // @Router			/api/v1/menus/{id} [get]
 func (c *menuController) FetchByID(ctx *gin.Context) {
@@ -91,15 +161,20 @@ func (c *menuController) FetchByID(ctx *gin.Context) {
 		return
 	}
 
+	ginlib.SetETag(ctx, menu.Version)
 	message = "successfully fetch menu"
 }
 
 // @Tags			Menus (Admin and Owner)
 // @Summary		Register Menu
-// @Description	Register Menu to System
+// @Description	Register Menu to System, photo can be uploaded with multipart/form-data
+// @Accept			json,mpfd
 // @Produce		json
 // @Param			MenuPayload	body		dto.MenuRequest	true	"Menu Register Payload"
 // @Success		200			{object}	ginlib.Response	"OK"
+// @Failure		403			{object}	ginlib.Response	"Forbidden"
+// @Failure		413			{object}	ginlib.Response	"File too large"
+// @Failure		415			{object}	ginlib.Response	"Unsupported file type"
 // @Failure		500			{object}	ginlib.Response	"Internal Server Error"
 // @Security		ApiKeyAuth
 // @Security		UserAuth
@@ -117,13 +192,16 @@ func (c *menuController) CreateMenu(ctx *gin.Context) {
 		ginlib.SendResponse(ctx, code, status, message, nil, err)
 	}()
 
-	if err = ctx.ShouldBindJSON(&menu); err != nil {
+	if err = ctx.ShouldBind(&menu); err != nil {
 		log.Info(nil, err.Error())
 		code, status = domain.GetStatus(err)
 		return
 	}
 
-	err = c.menuSvc.CreateMenu(&dto.MenuParams{}, &menu)
+	err = c.menuSvc.CreateMenu(&dto.MenuParams{
+		ActorID:   ctx.GetString("id"),
+		ActorRole: ctx.GetString("role_name"),
+	}, &menu)
 	code, status = domain.GetStatus(err)
 
 	if err != nil {
@@ -136,12 +214,20 @@ func (c *menuController) CreateMenu(ctx *gin.Context) {
 
 // @Tags			Menus (Admin and Owner)
 // @Summary		Update Menu
-// @Description	Update Existing Menu
+// @Description	Update Existing Menu, photo can be replaced with multipart/form-data. shop_id must be the current shop of the menu
+// @Accept			json,mpfd
 // @Produce		json
 // @Param			MenuPayload	body		dto.MenuRequest	true	"Menu Update Payload"
 // @Param			id			path		string			true	"Menu ID"
+// @Param			If-Match	header		string			true	"ETag of the item as last fetched"
 // @Success		200			{object}	ginlib.Response	"OK"
+// @Failure		400			{object}	ginlib.Response	"Bad Request"
+// @Failure		403			{object}	ginlib.Response	"Forbidden"
 // @Failure		404			{object}	ginlib.Response	"Item not found"
+// @Failure		412			{object}	ginlib.Response	"Item was changed since it was fetched"
+// @Failure		413			{object}	ginlib.Response	"File too large"
+// @Failure		415			{object}	ginlib.Response	"Unsupported file type"
+// @Failure		428			{object}	ginlib.Response	"If-Match header is missing"
 // @Failure		500			{object}	ginlib.Response	"Internal Server Error"
 // @Security		ApiKeyAuth
 // @Security		UserAuth
@@ -160,13 +246,23 @@ func (c *menuController) UpdateMenu(ctx *gin.Context) {
 		ginlib.SendResponse(ctx, code, status, message, nil, err)
 	}()
 
-	if err = ctx.ShouldBindJSON(&menu); err != nil {
+	versions, err := ginlib.ParseIfMatch(ctx)
+
+	if err != nil {
+		code, status = domain.GetStatus(err)
+		return
+	}
+
+	if err = ctx.ShouldBind(&menu); err != nil {
 		code, status = domain.GetStatus(err)
 		return
 	}
 
 	err = c.menuSvc.UpdateMenu(&dto.MenuParams{
-		ID: idParam,
+		ID:        idParam,
+		Versions:  versions,
+		ActorID:   ctx.GetString("id"),
+		ActorRole: ctx.GetString("role_name"),
 	}, &menu)
 	code, status = domain.GetStatus(err)
 
@@ -177,12 +273,72 @@ func (c *menuController) UpdateMenu(ctx *gin.Context) {
 	message = "successfully update menu"
 }
 
+// @Tags			Menus (Admin and Owner)
+// @Summary		Update Menu Options
+// @Description	Replace every option group of a Menu, like spice level or extra toppings
+// @Accept			json
+// @Produce		json
+// @Param			OptionsPayload	body		dto.MenuOptionsRequest	true	"Menu Options Payload"
+// @Param			id				path		string					true	"Menu ID"
+// @Param			If-Match		header		string					true	"ETag of the menu as last fetched"
+// @Success		200				{object}	ginlib.Response			"OK"
+// @Failure		400				{object}	ginlib.Response			"Bad Request"
+// @Failure		403				{object}	ginlib.Response			"Forbidden"
+// @Failure		404				{object}	ginlib.Response			"Item not found"
+// @Failure		412				{object}	ginlib.Response			"Item was changed since it was fetched"
+// @Failure		428				{object}	ginlib.Response			"If-Match header is missing"
+// @Failure		500				{object}	ginlib.Response			"Internal Server Error"
+// @Security		ApiKeyAuth
+// @Security		UserAuth
+// @Router			/api/v1/menus/{id}/options [put]
+func (c *menuController) UpdateMenuOptions(ctx *gin.Context) {
+	var (
+		code    = 400
+		status  = "fail"
+		message = "failed to update menu options"
+		req     dto.MenuOptionsRequest
+		err     error
+		idParam = ctx.Param("id")
+	)
+
+	defer func() {
+		ginlib.SendResponse(ctx, code, status, message, nil, err)
+	}()
+
+	versions, err := ginlib.ParseIfMatch(ctx)
+
+	if err != nil {
+		code, status = domain.GetStatus(err)
+		return
+	}
+
+	if err = ctx.ShouldBindJSON(&req); err != nil {
+		code, status = domain.GetStatus(domain.ErrBadRequest)
+		return
+	}
+
+	err = c.menuSvc.UpdateMenuOptions(&dto.MenuParams{
+		ID:        idParam,
+		Versions:  versions,
+		ActorID:   ctx.GetString("id"),
+		ActorRole: ctx.GetString("role_name"),
+	}, &req)
+	code, status = domain.GetStatus(err)
+
+	if err != nil {
+		return
+	}
+
+	message = "successfully update menu options"
+}
+
 // @Tags			Menus (Admin and Owner)
 // @Summary		Delete Menu
 // @Description	Delete Existing Menu from System
 // @Produce		json
 // @Param			id	path		string			true	"Menu ID"
 // @Success		200	{object}	ginlib.Response	"OK"
+// @Failure		403	{object}	ginlib.Response	"Forbidden"
 // @Failure		404	{object}	ginlib.Response	"Item not found"
 // @Failure		500	{object}	ginlib.Response	"Internal Server Error"
 // @Security		ApiKeyAuth
@@ -202,7 +358,9 @@ func (c *menuController) DeleteMenu(ctx *gin.Context) {
 	}()
 
 	err = c.menuSvc.DeleteMenu(&dto.MenuParams{
-		ID: idParam,
+		ID:        idParam,
+		ActorID:   ctx.GetString("id"),
+		ActorRole: ctx.GetString("role_name"),
 	})
 	code, status = domain.GetStatus(err)
 
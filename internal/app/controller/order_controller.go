@@ -1,6 +1,8 @@
 package controller
 
 import (
+	"io"
+
 	"github.com/devanfer02/filkom-canteen/domain"
 	"github.com/devanfer02/filkom-canteen/internal/app/service"
 	"github.com/devanfer02/filkom-canteen/internal/dto"
@@ -18,11 +20,17 @@ func MountOrderRoutes(r *gin.RouterGroup, orderSvc service.IOrderService, mdlwr
 	orderCtr := &orderController{orderSvc}
 	orderR := r.Group("/orders")
 
-	orderR.GET("", mdlwr.Authenticate(), orderCtr.FetchAll)
-	orderR.GET("/:id", mdlwr.Authenticate(), orderCtr.FetchByID)
-	orderR.POST("", mdlwr.Authenticate(), mdlwr.RateLimiter(50), orderCtr.CreateOrder)
+	orderR.GET("", mdlwr.Authenticate(), mdlwr.ResolveRole(), orderCtr.FetchAll)
+	orderR.GET("/events", mdlwr.Authenticate(), mdlwr.ResolveRole(), orderCtr.StreamEvents)
+	orderR.GET("/slots", mdlwr.Authenticate(), mdlwr.AuthorizeAdmin("Admin", "Owner"), orderCtr.FetchPickupSlots)
+	orderR.GET("/:id", mdlwr.Authenticate(), mdlwr.ResolveRole(), orderCtr.FetchByID)
+	orderR.GET("/:id/history", mdlwr.Authenticate(), mdlwr.ResolveRole(), orderCtr.FetchHistory)
+	orderR.POST("", mdlwr.Authenticate(), mdlwr.RateLimiter("order-create"), mdlwr.Idempotency(), orderCtr.CreateOrder)
 	orderR.PUT("/:id", mdlwr.Authenticate(), mdlwr.AuthorizeAdmin("Admin", "Owner"), orderCtr.UpdateOrder)
-	orderR.DELETE("/:id", mdlwr.Authenticate(), orderCtr.DeleteOrder)
+	orderR.POST("/:id/payment-proof", mdlwr.Authenticate(), mdlwr.RateLimiter("payment-proof"), mdlwr.Idempotency(), orderCtr.UploadPaymentProof)
+	orderR.POST("/:id/payment-proof/approve", mdlwr.Authenticate(), mdlwr.AuthorizeAdmin("Admin", "Owner"), orderCtr.ApprovePayment)
+	orderR.POST("/:id/payment-proof/reject", mdlwr.Authenticate(), mdlwr.AuthorizeAdmin("Admin", "Owner"), orderCtr.RejectPayment)
+	orderR.POST("/:id/cancel", mdlwr.Authenticate(), mdlwr.ResolveRole(), orderCtr.CancelOrder)
 }
 
 // @Tags			Orders
@@ -30,29 +38,42 @@ func MountOrderRoutes(r *gin.RouterGroup, orderSvc service.IOrderService, mdlwr
 // @Description	Fetch All Orders From Database
 // @Produce		json
 // @Param			shop_id	query		string									false	"Shop ID"
+// @Param			limit	query		int										false	"Page size, defaults to 20 and capped at 100"
+// @Param			cursor	query		string									false	"Cursor of the next page, also accepted as the X-Cursor header"
 // @Success		200		{object}	ginlib.Response{data=[]domain.Order}	"OK"
+// @Failure		400		{object}	ginlib.Response							"Invalid limit or cursor"
+// @Failure		403		{object}	ginlib.Response							"Forbidden"
 // @Failure		500		{object}	ginlib.Response							"Internal Server Error"
 // @Security		ApiKeyAuth
 // @Security		UserAuth
 // @Router			/api/v1/orders [get]
 func (c *orderController) FetchAll(ctx *gin.Context) {
 	var (
-		code    = 500
-		status  = "fail"
-		message = "failed to fetch all orders"
-		orders  []domain.Order
-		err     error
-		shopId  = ctx.Query("shop_id")
-		userID  = ctx.GetString("id")
-		user    = ctx.GetString("user")
+		code       = 500
+		status     = "fail"
+		message    = "failed to fetch all orders"
+		orders     []domain.Order
+		pagination *dto.Pagination
+		err        error
+		shopId     = ctx.Query("shop_id")
+		userID     = ctx.GetString("id")
+		user       = ctx.GetString("user")
 	)
 
 	defer func() {
-		ginlib.SendResponse(ctx, code, status, message, orders, err)
+		ginlib.SendPaginatedResponse(ctx, code, status, message, orders, pagination, err)
 	}()
 
-	orders, err = c.orderSvc.FetchAllOrders(&dto.OrderParams{
+	page, err := ginlib.ParsePage(ctx)
+
+	if err != nil {
+		code, status = domain.GetStatus(err)
+		return
+	}
+
+	orders, pagination, err = c.orderSvc.FetchAllOrders(&dto.OrderParams{
 		ShopID: shopId,
+		Page:   page,
 		UserID: func() string {
 			if user == env.AppEnv.JWTUserRole {
 				return userID
@@ -60,6 +81,9 @@ func (c *orderController) FetchAll(ctx *gin.Context) {
 
 			return ""
 		}(),
+		ActorID:   userID,
+		ActorType: user,
+		ActorRole: ctx.GetString("role_name"),
 	})
 
 	code, status = domain.GetStatus(err)
@@ -77,6 +101,8 @@ func (c *orderController) FetchAll(ctx *gin.Context) {
 // @Produce		json
 // @Param			id	path		string								true	"Order ID"
 // @Success		200	{object}	ginlib.Response{data=domain.Order}	"OK"
+// @Header			200	{string}	ETag								"Version of the item, send it as If-Match to update it"
+// @Failure		403	{object}	ginlib.Response						"Forbidden"
 // @Failure		404	{object}	ginlib.Response						"Item not found"
 // @Failure		500	{object}	ginlib.Response						"Internal Server Error"
 // @Security		ApiKeyAuth
@@ -96,48 +122,180 @@ func (c *orderController) FetchByID(ctx *gin.Context) {
 		ginlib.SendResponse(ctx, code, status, message, order, err)
 	}()
 
-	order, err = c.orderSvc.FetchOrderByID(&dto.OrderParams{ID: idParam})
+	order, err = c.orderSvc.FetchOrderByID(&dto.OrderParams{
+		ID:        idParam,
+		ActorID:   ctx.GetString("id"),
+		ActorType: ctx.GetString("user"),
+		ActorRole: ctx.GetString("role_name"),
+	})
 	code, status = domain.GetStatus(err)
 
 	if err != nil {
 		return
 	}
 
+	ginlib.SetETag(ctx, order.Version)
 	message = "successfully fetch order"
 }
 
 // @Tags			Orders
-// @Summary		Register Order
-// @Description	Register Order to System
+// @Summary		Stream Order Events
+// @Description	Stream order changes as Server-Sent Events. Without shop_id the caller receives the events of their own orders, with shop_id owners and admins receive every order event of that Shop. Each event is named after its type (order.created, order.status_changed, order.payment_updated) and carries the order as data
+// @Produce		text/event-stream
+// @Param			shop_id	query		string			false	"Shop ID"
+// @Success		200		{string}	string			"Event stream"
+// @Failure		400		{object}	ginlib.Response	"Bad Request"
+// @Failure		403		{object}	ginlib.Response	"Forbidden"
+// @Failure		500		{object}	ginlib.Response	"Internal Server Error"
+// @Security		ApiKeyAuth
+// @Security		UserAuth
+// @Router			/api/v1/orders/events [get]
+func (c *orderController) StreamEvents(ctx *gin.Context) {
+	stream, unsubscribe, err := c.orderSvc.SubscribeOrderEvents(&dto.OrderParams{
+		ShopID:    ctx.Query("shop_id"),
+		ActorID:   ctx.GetString("id"),
+		ActorType: ctx.GetString("user"),
+		ActorRole: ctx.GetString("role_name"),
+	})
+
+	if err != nil {
+		code, status := domain.GetStatus(err)
+		ginlib.SendResponse(ctx, code, status, "failed to subscribe to order events", nil, err)
+		return
+	}
+
+	defer unsubscribe()
+
+	ginlib.SendEventStream(ctx, stream)
+}
+
+// @Tags			Orders (Admin and Owner)
+// @Summary		Fetch Pickup Slots
+// @Description	Fetch the active pre-orders of a Shop for one day grouped by pickup slot, with the quantities to prepare per slot
 // @Produce		json
-// @Param			OrderPayload	body		dto.OrderRequest	true	"Order Register Payload"
-// @Success		200				{object}	ginlib.Response		"OK"
-// @Failure		500				{object}	ginlib.Response		"Internal Server Error"
+// @Param			shop_id	query		string										true	"Shop ID"
+// @Param			date	query		string										false	"Day as YYYY-MM-DD, defaults to today starting from the current slot"
+// @Success		200		{object}	ginlib.Response{data=[]domain.PickupSlot}	"OK"
+// @Failure		400		{object}	ginlib.Response								"Bad Request"
+// @Failure		403		{object}	ginlib.Response								"Forbidden"
+// @Failure		500		{object}	ginlib.Response								"Internal Server Error"
 // @Security		ApiKeyAuth
 // @Security		UserAuth
-// @Router			/api/v1/orders [post]
-func (c *orderController) CreateOrder(ctx *gin.Context) {
+// @Router			/api/v1/orders/slots [get]
+func (c *orderController) FetchPickupSlots(ctx *gin.Context) {
+	var (
+		code    = 400
+		status  = "fail"
+		message = "failed to fetch pickup slots"
+		slots   []domain.PickupSlot
+		err     error
+		shopID  = ctx.Query("shop_id")
+	)
+
+	defer func() {
+		ginlib.SendResponse(ctx, code, status, message, slots, err)
+	}()
+
+	if shopID == "" {
+		err = domain.ErrBadRequest
+		code, status = domain.GetStatus(err)
+		return
+	}
+
+	slots, err = c.orderSvc.FetchPickupSlots(&dto.OrderParams{
+		ShopID:    shopID,
+		ActorID:   ctx.GetString("id"),
+		ActorRole: ctx.GetString("role_name"),
+	}, ctx.Query("date"))
+	code, status = domain.GetStatus(err)
+
+	if err != nil {
+		return
+	}
+
+	message = "successfully fetch pickup slots"
+}
+
+// @Tags			Orders
+// @Summary		Fetch Order Status History
+// @Description	Fetch Status Transition Timeline of an Order
+// @Produce		json
+// @Param			id	path		string												true	"Order ID"
+// @Success		200	{object}	ginlib.Response{data=[]domain.OrderStatusHistory}	"OK"
+// @Failure		403	{object}	ginlib.Response										"Forbidden"
+// @Failure		404	{object}	ginlib.Response										"Item not found"
+// @Failure		500	{object}	ginlib.Response										"Internal Server Error"
+// @Security		ApiKeyAuth
+// @Security		UserAuth
+// @Router			/api/v1/orders/{id}/history [get]
+func (c *orderController) FetchHistory(ctx *gin.Context) {
 	var (
 		code    = 500
 		status  = "fail"
-		message = "failed to create order"
-		order   dto.OrderRequest
+		message = "failed to fetch order history"
+		history []domain.OrderStatusHistory
 		err     error
-		userId  = ctx.GetString("id")
+		idParam = ctx.Param("id")
 	)
 
 	defer func() {
-		ginlib.SendResponse(ctx, code, status, message, nil, err)
+		ginlib.SendResponse(ctx, code, status, message, history, err)
 	}()
 
-	if err = ctx.ShouldBindJSON(&order); err != nil {
+	history, err = c.orderSvc.FetchOrderHistory(&dto.OrderParams{
+		ID:        idParam,
+		ActorID:   ctx.GetString("id"),
+		ActorType: ctx.GetString("user"),
+		ActorRole: ctx.GetString("role_name"),
+	})
+	code, status = domain.GetStatus(err)
+
+	if err != nil {
+		return
+	}
+
+	message = "successfully fetch order history"
+}
+
+// @Tags			Orders
+// @Summary		Register Order
+// @Description	Register Order with one or more menu items to System
+// @Produce		json
+// @Param			OrderPayload	body		dto.OrderRequest					true	"Order Register Payload"
+// @Param			Idempotency-Key	header		string								false	"Replays the first response when the same request is sent again with this key"
+// @Success		200				{object}	ginlib.Response{data=domain.Order}	"OK"
+// @Failure		400				{object}	ginlib.Response						"Bad Request"
+// @Failure		409				{object}	ginlib.Response						"Menu is out of stock, shop is closed or pickup slot is full"
+// @Failure		422				{object}	ginlib.Response						"Idempotency key was used for a different request"
+// @Failure		429				{object}	ginlib.Response						"Rate limit exceeded, see Retry-After"
+// @Failure		500				{object}	ginlib.Response						"Internal Server Error"
+// @Security		ApiKeyAuth
+// @Security		UserAuth
+// @Router			/api/v1/orders [post]
+func (c *orderController) CreateOrder(ctx *gin.Context) {
+	var (
+		code     = 500
+		status   = "fail"
+		message  = "failed to create order"
+		orderReq dto.OrderRequest
+		order    *domain.Order
+		err      error
+		userId   = ctx.GetString("id")
+	)
+
+	defer func() {
+		ginlib.SendResponse(ctx, code, status, message, order, err)
+	}()
+
+	if err = ctx.ShouldBindJSON(&orderReq); err != nil {
 		code, status = domain.GetStatus(err)
 		return
 	}
 
-	err = c.orderSvc.CreateOrder(&dto.OrderParams{
-		UserID: userId,
-	}, &order)
+	order, err = c.orderSvc.CreateOrder(&dto.OrderParams{
+		UserID:    userId,
+		ActorType: ctx.GetString("user"),
+	}, &orderReq)
 	code, status = domain.GetStatus(err)
 
 	if err != nil {
@@ -150,13 +308,18 @@ func (c *orderController) CreateOrder(ctx *gin.Context) {
 
 // @Tags			Orders (Admin and Owner)
 // @Summary		Update Order
-// @Description	Update Existing Order
+// @Description	Update Existing Order, moving it to Cancelled works like the cancel action and needs a reason
 // @Produce		json
-// @Param			OrderPayload	body		dto.OrderRequest	true	"Order Update Payload"
-// @Param			id				path		string				true	"Order ID"
-// @Success		200				{object}	ginlib.Response		"OK"
-// @Failure		404				{object}	ginlib.Response		"Item not found"
-// @Failure		500				{object}	ginlib.Response		"Internal Server Error"
+// @Param			OrderPayload	body		dto.OrderUpdateRequest	true	"Order Update Payload"
+// @Param			id				path		string					true	"Order ID"
+// @Param			If-Match		header		string					true	"ETag of the item as last fetched"
+// @Success		200				{object}	ginlib.Response			"OK"
+// @Failure		403				{object}	ginlib.Response			"Forbidden"
+// @Failure		404				{object}	ginlib.Response			"Item not found"
+// @Failure		409				{object}	ginlib.Response			"Invalid status transition or menu out of stock"
+// @Failure		412				{object}	ginlib.Response			"Item was changed since it was fetched"
+// @Failure		428				{object}	ginlib.Response			"If-Match header is missing"
+// @Failure		500				{object}	ginlib.Response			"Internal Server Error"
 // @Security		ApiKeyAuth
 // @Security		UserAuth
 // @Router			/api/v1/orders [put]
@@ -165,7 +328,7 @@ func (c *orderController) UpdateOrder(ctx *gin.Context) {
 		code    = 500
 		status  = "fail"
 		message = "failed to fetch order"
-		order   dto.OrderRequest
+		order   dto.OrderUpdateRequest
 		err     error
 		idParam = ctx.Param("id")
 	)
@@ -174,13 +337,24 @@ func (c *orderController) UpdateOrder(ctx *gin.Context) {
 		ginlib.SendResponse(ctx, code, status, message, nil, err)
 	}()
 
+	versions, err := ginlib.ParseIfMatch(ctx)
+
+	if err != nil {
+		code, status = domain.GetStatus(err)
+		return
+	}
+
 	if err = ctx.ShouldBindJSON(&order); err != nil {
 		code, status = domain.GetStatus(err)
 		return
 	}
 
 	err = c.orderSvc.UpdateOrder(&dto.OrderParams{
-		ID: idParam,
+		ID:        idParam,
+		Versions:  versions,
+		ActorID:   ctx.GetString("id"),
+		ActorType: ctx.GetString("user"),
+		ActorRole: ctx.GetString("role_name"),
 	}, &order)
 	code, status = domain.GetStatus(err)
 
@@ -192,40 +366,206 @@ func (c *orderController) UpdateOrder(ctx *gin.Context) {
 }
 
 // @Tags			Orders
-// @Summary		Delete Order
-// @Description	Delete Existing Order from System
+// @Summary		Upload Payment Proof
+// @Description	Upload QRIS Payment Proof Image of a Waiting Order
+// @Accept			multipart/form-data
 // @Produce		json
-// @Param			id	path		string			true	"Order ID"
-// @Success		200	{object}	ginlib.Response	"OK"
-// @Failure		404	{object}	ginlib.Response	"Item not found"
-// @Failure		500	{object}	ginlib.Response	"Internal Server Error"
+// @Param			id				path		string								true	"Order ID"
+// @Param			payment_proof	formData	file								true	"Payment proof image (jpeg, png or webp)"
+// @Param			Idempotency-Key	header		string								false	"Replays the first response when the same request is sent again with this key"
+// @Success		200				{object}	ginlib.Response{data=domain.Order}	"OK"
+// @Failure		400				{object}	ginlib.Response						"Bad Request"
+// @Failure		403				{object}	ginlib.Response						"Forbidden"
+// @Failure		404				{object}	ginlib.Response						"Item not found"
+// @Failure		409				{object}	ginlib.Response						"Payment can not be submitted"
+// @Failure		413				{object}	ginlib.Response						"File too large"
+// @Failure		415				{object}	ginlib.Response						"Unsupported file type"
+// @Failure		422				{object}	ginlib.Response						"Idempotency key was used for a different request"
+// @Failure		429				{object}	ginlib.Response						"Rate limit exceeded, see Retry-After"
+// @Failure		500				{object}	ginlib.Response						"Internal Server Error"
 // @Security		ApiKeyAuth
 // @Security		UserAuth
-// @Router			/api/v1/orders [delete]
-func (c *orderController) DeleteOrder(ctx *gin.Context) {
+// @Router			/api/v1/orders/{id}/payment-proof [post]
+func (c *orderController) UploadPaymentProof(ctx *gin.Context) {
 	var (
-		code    = 500
-		status  = "fail"
-		message = "failed to delete order"
-		err     error
-		idParam = ctx.Param("id")
-		userId  = ctx.GetString("id")
+		code     = 500
+		status   = "fail"
+		message  = "failed to upload payment proof"
+		proofReq dto.PaymentProofRequest
+		order    *domain.Order
+		err      error
+		idParam  = ctx.Param("id")
 	)
 
 	defer func() {
-		ginlib.SendResponse(ctx, code, status, message, nil, err)
+		ginlib.SendResponse(ctx, code, status, message, order, err)
 	}()
 
-	err = c.orderSvc.DeleteOrder(&dto.OrderParams{
+	if err = ctx.ShouldBind(&proofReq); err != nil {
+		code, status = domain.GetStatus(domain.ErrBadRequest)
+		return
+	}
+
+	order, err = c.orderSvc.UploadPaymentProof(&dto.OrderParams{
 		ID:     idParam,
-		UserID: userId,
-	})
+		UserID: ctx.GetString("id"),
+	}, &proofReq)
+	code, status = domain.GetStatus(err)
+
+	if err != nil {
+		return
+	}
+
+	message = "successfully upload payment proof"
+}
+
+// @Tags			Orders (Admin and Owner)
+// @Summary		Approve Payment
+// @Description	Approve Submitted Payment Proof and Accept the Order
+// @Produce		json
+// @Param			PaymentPayload	body		dto.PaymentVerificationRequest	false	"Optional note for the student"
+// @Param			id				path		string							true	"Order ID"
+// @Success		200				{object}	ginlib.Response					"OK"
+// @Failure		403				{object}	ginlib.Response					"Forbidden"
+// @Failure		404				{object}	ginlib.Response					"Item not found"
+// @Failure		409				{object}	ginlib.Response					"Payment is not awaiting verification or menu out of stock"
+// @Failure		500				{object}	ginlib.Response					"Internal Server Error"
+// @Security		ApiKeyAuth
+// @Security		UserAuth
+// @Router			/api/v1/orders/{id}/payment-proof/approve [post]
+func (c *orderController) ApprovePayment(ctx *gin.Context) {
+	var (
+		code      = 500
+		status    = "fail"
+		message   = "failed to approve payment"
+		verifyReq dto.PaymentVerificationRequest
+		err       error
+		idParam   = ctx.Param("id")
+	)
+
+	defer func() {
+		ginlib.SendResponse(ctx, code, status, message, nil, err)
+	}()
+
+	// the note is optional, so an empty body is fine
+	if ctx.Request.ContentLength > 0 {
+		if err = ctx.ShouldBindJSON(&verifyReq); err != nil {
+			code, status = domain.GetStatus(err)
+			return
+		}
+	}
+
+	err = c.orderSvc.ApprovePayment(&dto.OrderParams{
+		ID:        idParam,
+		ActorID:   ctx.GetString("id"),
+		ActorType: ctx.GetString("user"),
+		ActorRole: ctx.GetString("role_name"),
+	}, &verifyReq)
 	code, status = domain.GetStatus(err)
 
 	if err != nil {
+		return
+	}
+
+	message = "successfully approve payment"
+}
+
+// @Tags			Orders (Admin and Owner)
+// @Summary		Reject Payment
+// @Description	Reject Submitted Payment Proof with a Reason Shown to the Student
+// @Produce		json
+// @Param			PaymentPayload	body		dto.PaymentVerificationRequest	true	"Rejection reason"
+// @Param			id				path		string							true	"Order ID"
+// @Success		200				{object}	ginlib.Response					"OK"
+// @Failure		400				{object}	ginlib.Response					"Reason is required"
+// @Failure		403				{object}	ginlib.Response					"Forbidden"
+// @Failure		404				{object}	ginlib.Response					"Item not found"
+// @Failure		409				{object}	ginlib.Response					"Payment is not awaiting verification"
+// @Failure		500				{object}	ginlib.Response					"Internal Server Error"
+// @Security		ApiKeyAuth
+// @Security		UserAuth
+// @Router			/api/v1/orders/{id}/payment-proof/reject [post]
+func (c *orderController) RejectPayment(ctx *gin.Context) {
+	var (
+		code      = 500
+		status    = "fail"
+		message   = "failed to reject payment"
+		verifyReq dto.PaymentVerificationRequest
+		err       error
+		idParam   = ctx.Param("id")
+	)
+
+	defer func() {
+		ginlib.SendResponse(ctx, code, status, message, nil, err)
+	}()
+
+	if err = ctx.ShouldBindJSON(&verifyReq); err != nil {
 		code, status = domain.GetStatus(err)
 		return
 	}
 
-	message = "successfully delete order"
+	err = c.orderSvc.RejectPayment(&dto.OrderParams{
+		ID:        idParam,
+		ActorID:   ctx.GetString("id"),
+		ActorType: ctx.GetString("user"),
+		ActorRole: ctx.GetString("role_name"),
+	}, &verifyReq)
+	code, status = domain.GetStatus(err)
+
+	if err != nil {
+		return
+	}
+
+	message = "successfully reject payment"
+}
+
+// @Tags			Orders
+// @Summary		Cancel Order
+// @Description	Cancel an Order while keeping it in the history. Students may cancel their own order only while it is Waiting, owners and admins may cancel any order of their Shop before pickup and must give a reason
+// @Accept			json
+// @Produce		json
+// @Param			CancelPayload	body		dto.OrderCancelRequest	false	"Cancellation reason, required for owners and admins"
+// @Param			id				path		string					true	"Order ID"
+// @Success		200				{object}	ginlib.Response			"OK"
+// @Failure		400				{object}	ginlib.Response			"Reason is required"
+// @Failure		403				{object}	ginlib.Response			"Forbidden"
+// @Failure		404				{object}	ginlib.Response			"Item not found"
+// @Failure		409				{object}	ginlib.Response			"Order can no longer be cancelled"
+// @Failure		500				{object}	ginlib.Response			"Internal Server Error"
+// @Security		ApiKeyAuth
+// @Security		UserAuth
+// @Router			/api/v1/orders/{id}/cancel [post]
+func (c *orderController) CancelOrder(ctx *gin.Context) {
+	var (
+		code      = 500
+		status    = "fail"
+		message   = "failed to cancel order"
+		cancelReq dto.OrderCancelRequest
+		err       error
+		idParam   = ctx.Param("id")
+	)
+
+	defer func() {
+		ginlib.SendResponse(ctx, code, status, message, nil, err)
+	}()
+
+	// students may cancel without sending a body at all
+	if err = ctx.ShouldBindJSON(&cancelReq); err != nil && err != io.EOF {
+		code, status = domain.GetStatus(domain.ErrBadRequest)
+		return
+	}
+
+	err = c.orderSvc.CancelOrder(&dto.OrderParams{
+		ID:        idParam,
+		ActorID:   ctx.GetString("id"),
+		ActorType: ctx.GetString("user"),
+		ActorRole: ctx.GetString("role_name"),
+	}, &cancelReq)
+	code, status = domain.GetStatus(err)
+
+	if err != nil {
+		return
+	}
+
+	message = "successfully cancel order"
 }
@@ -28,24 +28,35 @@ func MountOwnerRoutes(r *gin.RouterGroup, ownerSvc service.IOwnerService, mdlwr
 // @Summary		Fetch All Owners
 // @Description	Fetch All Owners From Database
 // @Produce		json
-// @Success		200	{object}	ginlib.Response{data=[]domain.Owner}	"OK"
-// @Failure		500	{object}	ginlib.Response							"Internal Server Error"
+// @Param			limit	query		int										false	"Page size, defaults to 20 and capped at 100"
+// @Param			cursor	query		string									false	"Cursor of the next page, also accepted as the X-Cursor header"
+// @Success		200		{object}	ginlib.Response{data=[]domain.Owner}	"OK"
+// @Failure		400		{object}	ginlib.Response							"Invalid limit or cursor"
+// @Failure		500		{object}	ginlib.Response							"Internal Server Error"
 // @Security		ApiKeyAuth
 // @Router			/api/v1/owners [get]
 func (c *ownerController) FetchAll(ctx *gin.Context) {
 	var (
-		code    = 500
-		status  = "fail"
-		message = "failed to fetch all owners"
-		owners  []domain.Owner
-		err     error
+		code       = 500
+		status     = "fail"
+		message    = "failed to fetch all owners"
+		owners     []domain.Owner
+		pagination *dto.Pagination
+		err        error
 	)
 
 	defer func() {
-		ginlib.SendResponse(ctx, code, status, message, owners, err)
+		ginlib.SendPaginatedResponse(ctx, code, status, message, owners, pagination, err)
 	}()
 
-	owners, err = c.ownerSvc.FetchAllOwners()
+	page, err := ginlib.ParsePage(ctx)
+
+	if err != nil {
+		code, status = domain.GetStatus(err)
+		return
+	}
+
+	owners, pagination, err = c.ownerSvc.FetchAllOwners(&dto.OwnerParams{Page: page})
 	code, status = domain.GetStatus(err)
 
 	if err != nil {
@@ -61,6 +72,7 @@ func (c *ownerController) FetchAll(ctx *gin.Context) {
 // @Produce		json
 // @Param			id	path		string								true	"Owner ID"
 // @Success		200	{object}	ginlib.Response{data=domain.Owner}	"OK"
+// @Header			200	{string}	ETag								"Version of the item, send it as If-Match to update it"
 // @Failure		404	{object}	ginlib.Response{data=domain.Owner}	"Item not found"
 // @Failure		500	{object}	ginlib.Response						"Internal Server Error"
 // @Security		ApiKeyAuth
@@ -86,6 +98,7 @@ func (c *ownerController) FetchByID(ctx *gin.Context) {
 		return
 	}
 
+	ginlib.SetETag(ctx, owner.Version)
 	message = "successfully fetch owner"
 }
 
@@ -134,9 +147,13 @@ func (c *ownerController) RegisterOwner(ctx *gin.Context) {
 // @Produce		json
 // @Param			OwnerPayload	body		dto.OwnerRequest					true	"Owner Register Payload"
 // @Param			id				path		string								true	"Owner ID"
+// @Param			If-Match		header		string								true	"ETag of the item as last fetched"
 // @Success		200				{object}	ginlib.Response						"OK"
+// @Failure		403				{object}	ginlib.Response						"Forbidden"
 // @Failure		404				{object}	ginlib.Response{data=domain.Owner}	"Item not found"
 // @Failure		409				{object}	ginlib.Response						"Username already exists"
+// @Failure		412				{object}	ginlib.Response						"Item was changed since it was fetched"
+// @Failure		428				{object}	ginlib.Response						"If-Match header is missing"
 // @Failure		500				{object}	ginlib.Response						"Internal Server Error"
 // @Security		ApiKeyAuth
 // @Router			/api/v1/owners/{id} [put]
@@ -154,13 +171,23 @@ func (c *ownerController) UpdateOwner(ctx *gin.Context) {
 		ginlib.SendResponse(ctx, code, status, message, nil, err)
 	}()
 
+	versions, err := ginlib.ParseIfMatch(ctx)
+
+	if err != nil {
+		code, status = domain.GetStatus(err)
+		return
+	}
+
 	if err = ctx.ShouldBindJSON(&owner); err != nil {
 		code, status = domain.GetStatus(err)
 		return
 	}
 
 	err = c.ownerSvc.UpdateOwner(&dto.OwnerParams{
-		ID: idParam,
+		ID:        idParam,
+		Versions:  versions,
+		ActorID:   ctx.GetString("id"),
+		ActorRole: ctx.GetString("role_name"),
 	}, &owner)
 	code, status = domain.GetStatus(err)
 
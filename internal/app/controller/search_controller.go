@@ -0,0 +1,62 @@
+package controller
+
+import (
+	"github.com/devanfer02/filkom-canteen/domain"
+	"github.com/devanfer02/filkom-canteen/internal/app/service"
+	"github.com/devanfer02/filkom-canteen/internal/dto"
+	"github.com/devanfer02/filkom-canteen/internal/middleware"
+	ginlib "github.com/devanfer02/filkom-canteen/internal/pkg/gin"
+	"github.com/gin-gonic/gin"
+)
+
+type searchController struct {
+	searchSvc service.ISearchService
+}
+
+func MountSearchRoutes(r *gin.RouterGroup, searchSvc service.ISearchService, mdlwr *middleware.Middleware) {
+	searchCtr := &searchController{searchSvc}
+	searchR := r.Group("/search")
+
+	searchR.GET("", mdlwr.RateLimiter("search"), searchCtr.Search)
+}
+
+// @Tags			Search
+// @Summary		Search Menus and Shops
+// @Description	Full Text and Fuzzy Search over Menu Names, Shop Names and Shop Descriptions, Ranked and Grouped by Shop
+// @Produce		json
+// @Param			q		query		string										true	"Search keywords, at least 2 characters"
+// @Param			limit	query		int											false	"Maximum shops returned, defaults to 10 and capped at 30"
+// @Success		200		{object}	ginlib.Response{data=[]domain.SearchResult}	"OK"
+// @Failure		400		{object}	ginlib.Response								"Bad Request"
+// @Failure		429		{object}	ginlib.Response								"Rate limit exceeded, see Retry-After"
+// @Failure		500		{object}	ginlib.Response								"Internal Server Error"
+// @Security		ApiKeyAuth
+// @Router			/api/v1/search [get]
+func (c *searchController) Search(ctx *gin.Context) {
+	var (
+		code    = 500
+		status  = "fail"
+		message = "failed to search"
+		results []domain.SearchResult
+		params  dto.SearchParams
+		err     error
+	)
+
+	defer func() {
+		ginlib.SendResponse(ctx, code, status, message, results, err)
+	}()
+
+	if err = ctx.ShouldBindQuery(&params); err != nil {
+		code, status = domain.GetStatus(domain.ErrBadRequest)
+		return
+	}
+
+	results, err = c.searchSvc.Search(&params)
+	code, status = domain.GetStatus(err)
+
+	if err != nil {
+		return
+	}
+
+	message = "successfully search menus and shops"
+}
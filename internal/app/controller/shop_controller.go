@@ -17,8 +17,8 @@ func MountShopRoutes(r *gin.RouterGroup, shopSvc service.IShopService, mdlwr *mi
 	shopCtr := &shopController{shopSvc}
 
 	shopR := r.Group("/shops")
-	shopR.GET("", shopCtr.FetchAllShops)
-	shopR.GET("/:id", shopCtr.FetchShopByID)
+	shopR.GET("", mdlwr.CacheResponse(), shopCtr.FetchAllShops)
+	shopR.GET("/:id", mdlwr.CacheResponse(), shopCtr.FetchShopByID)
 	shopR.POST("", mdlwr.Authenticate(), mdlwr.AuthorizeAdmin("Admin"), shopCtr.CreateShop)
 	shopR.POST("/:id/owners/:ownerId", mdlwr.Authenticate(), mdlwr.AuthorizeAdmin("Admin"), shopCtr.AssignOwner)
 	shopR.DELETE("/:id/owners/:ownerId", mdlwr.Authenticate(), mdlwr.AuthorizeAdmin("Admin"), shopCtr.RemoveOwner)
@@ -30,25 +30,39 @@ func MountShopRoutes(r *gin.RouterGroup, shopSvc service.IShopService, mdlwr *mi
 // @Summary		Fetch All Shops
 // @Description	Fetch All Shops From Database
 // @Produce		json
-// @Success		200	{object}	ginlib.Response{data=[]domain.Shop}	"OK"
-// @Failure		500	{object}	ginlib.Response						"Internal Server Error"
+// @Param			limit			query		int									false	"Page size, defaults to 20 and capped at 100"
+// @Param			cursor			query		string								false	"Cursor of the next page, also accepted as the X-Cursor header"
+// @Param			If-None-Match	header		string								false	"ETag of a previously fetched response"
+// @Success		200				{object}	ginlib.Response{data=[]domain.Shop}	"OK"
+// @Header			200				{string}	ETag								"Version of the response for If-None-Match"
+// @Success		304				"Not Modified, the cached response is still current"
+// @Failure		400				{object}	ginlib.Response	"Invalid limit or cursor"
+// @Failure		500				{object}	ginlib.Response	"Internal Server Error"
 // @Security		ApiKeyAuth
 // @Security		UserAuth
 // @Router			/api/v1/shops [get]
 func (c *shopController) FetchAllShops(ctx *gin.Context) {
 	var (
-		code    = 500
-		status  = "fail"
-		message = "failed to fetch all shops"
-		shops   []domain.Shop
-		err     error
+		code       = 500
+		status     = "fail"
+		message    = "failed to fetch all shops"
+		shops      []domain.Shop
+		pagination *dto.Pagination
+		err        error
 	)
 
 	defer func() {
-		ginlib.SendResponse(ctx, code, status, message, shops, err)
+		ginlib.SendPaginatedResponse(ctx, code, status, message, shops, pagination, err)
 	}()
 
-	shops, err = c.shopSvc.FetchAllShops()
+	page, err := ginlib.ParsePage(ctx)
+
+	if err != nil {
+		code, status = domain.GetStatus(err)
+		return
+	}
+
+	shops, pagination, err = c.shopSvc.FetchAllShops(&dto.ShopParams{Page: page})
 	code, status = domain.GetStatus(err)
 
 	if err != nil {
@@ -63,10 +77,13 @@ func (c *shopController) FetchAllShops(ctx *gin.Context) {
 // @Summary		Fetch Shop By ID
 // @Description	Fetch Shop By ID From DB
 // @Produce		json
-// @Param			id	path		string								true	"Shop ID"
-// @Success		200	{object}	ginlib.Response{data=domain.Shop}	"OK"
-// @Failure		404	{object}	ginlib.Response{data=domain.Shop}	"Item not found"
-// @Failure		500	{object}	ginlib.Response						"Internal Server Error"
+// @Param			id				path		string								true	"Shop ID"
+// @Param			If-None-Match	header		string								false	"ETag of a previously fetched response"
+// @Success		200				{object}	ginlib.Response{data=domain.Shop}	"OK"
+// @Header			200				{string}	ETag								"Version and open state of the item, for If-None-Match and for If-Match on updates"
+// @Success		304				"Not Modified, the cached response is still current"
+// @Failure		404				{object}	ginlib.Response{data=domain.Shop}	"Item not found"
+// @Failure		500				{object}	ginlib.Response						"Internal Server Error"
 // @Security		ApiKeyAuth
 // @Security		UserAuth
 // @Router			/api/v1/shops/{id} [get]
@@ -93,15 +110,26 @@ func (c *shopController) FetchShopByID(ctx *gin.Context) {
 		return
 	}
 
+	// is_open follows the clock, not the version
+	openState := "closed"
+
+	if shop.IsOpen {
+		openState = "open"
+	}
+
+	ginlib.SetETag(ctx, shop.Version, openState)
 	message = "successfully fetch shop by id"
 }
 
 // @Tags			Shops (Admin only)
 // @Summary		Register Shop
-// @Description	Register Shop to System
+// @Description	Register Shop to System, photo can be uploaded with multipart/form-data
+// @Accept			json,mpfd
 // @Produce		json
 // @Param			ShopPayload	body		dto.ShopRequest	true	"Shop Register Payload"
 // @Success		200			{object}	ginlib.Response	"OK"
+// @Failure		413			{object}	ginlib.Response	"File too large"
+// @Failure		415			{object}	ginlib.Response	"Unsupported file type"
 // @Failure		500			{object}	ginlib.Response	"Internal Server Error"
 // @Security		ApiKeyAuth
 // @Security		UserAuth
@@ -119,7 +147,7 @@ func (c *shopController) CreateShop(ctx *gin.Context) {
 		ginlib.SendResponse(ctx, code, status, message, nil, err)
 	}()
 
-	if err := ctx.ShouldBindJSON(&shopReq); err != nil {
+	if err := ctx.ShouldBind(&shopReq); err != nil {
 		code, status = domain.GetStatus(err)
 		return
 	}
@@ -216,13 +244,20 @@ func (c *shopController) RemoveOwner(ctx *gin.Context) {
 
 // @Tags			Shops (Admin and Owner)
 // @Summary		Update Shop
-// @Description	Update Existing Shop
+// @Description	Update Existing Shop, photo can be replaced with multipart/form-data
+// @Accept			json,mpfd
 // @Produce		json
 // @Param			ShopPayload	body		dto.ShopRequest						true	"Shop Register Payload"
 // @Param			id			path		string								true	"Shop ID"
+// @Param			If-Match	header		string								true	"ETag of the item as last fetched"
 // @Success		200			{object}	ginlib.Response						"OK"
+// @Failure		403			{object}	ginlib.Response						"Forbidden"
 // @Failure		404			{object}	ginlib.Response{data=domain.Shop}	"Item not found"
 // @Failure		409			{object}	ginlib.Response						"Username already exists"
+// @Failure		412			{object}	ginlib.Response						"Item was changed since it was fetched"
+// @Failure		413			{object}	ginlib.Response						"File too large"
+// @Failure		415			{object}	ginlib.Response						"Unsupported file type"
+// @Failure		428			{object}	ginlib.Response						"If-Match header is missing"
 // @Failure		500			{object}	ginlib.Response						"Internal Server Error"
 // @Security		ApiKeyAuth
 // @Security		UserAuth
@@ -241,12 +276,24 @@ func (c *shopController) UpdateShop(ctx *gin.Context) {
 		ginlib.SendResponse(ctx, code, status, message, nil, err)
 	}()
 
-	if err := ctx.ShouldBindJSON(&shopReq); err != nil {
+	versions, err := ginlib.ParseIfMatch(ctx)
+
+	if err != nil {
+		code, status = domain.GetStatus(err)
+		return
+	}
+
+	if err := ctx.ShouldBind(&shopReq); err != nil {
 		code, status = domain.GetStatus(err)
 		return
 	}
 
-	err = c.shopSvc.UpdateShop(&dto.ShopParams{ID: idParam}, &shopReq)
+	err = c.shopSvc.UpdateShop(&dto.ShopParams{
+		ID:        idParam,
+		Versions:  versions,
+		ActorID:   ctx.GetString("id"),
+		ActorRole: ctx.GetString("role_name"),
+	}, &shopReq)
 	code, status = domain.GetStatus(err)
 
 	if err != nil {
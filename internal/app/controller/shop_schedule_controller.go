@@ -0,0 +1,234 @@
+package controller
+
+import (
+	"github.com/devanfer02/filkom-canteen/domain"
+	"github.com/devanfer02/filkom-canteen/internal/app/service"
+	"github.com/devanfer02/filkom-canteen/internal/dto"
+	"github.com/devanfer02/filkom-canteen/internal/middleware"
+	ginlib "github.com/devanfer02/filkom-canteen/internal/pkg/gin"
+	"github.com/gin-gonic/gin"
+)
+
+type shopScheduleController struct {
+	scheduleSvc service.IShopScheduleService
+}
+
+func MountShopScheduleRoutes(r *gin.RouterGroup, scheduleSvc service.IShopScheduleService, mdlwr *middleware.Middleware) {
+	scheduleCtr := &shopScheduleController{scheduleSvc}
+	scheduleR := r.Group("/shops/:id", mdlwr.Authenticate(), mdlwr.AuthorizeAdmin("Admin", "Owner"))
+
+	scheduleR.PUT("/hours", scheduleCtr.UpdateHours)
+	scheduleR.POST("/closures", scheduleCtr.AddClosure)
+	scheduleR.DELETE("/closures/:closureId", scheduleCtr.RemoveClosure)
+	scheduleR.PUT("/status", scheduleCtr.UpdateStatus)
+}
+
+// @Tags			Shop Schedule (Admin and Owner)
+// @Summary		Update Shop Opening Hours
+// @Description	Replace the weekly opening hours of a Shop, weekday 0 is Sunday and times are HH:MM in Asia/Jakarta. A shop without hours is always open
+// @Accept			json
+// @Produce		json
+// @Param			HoursPayload	body		dto.ShopHoursRequest	true	"Opening Hours Payload"
+// @Param			id				path		string					true	"Shop ID"
+// @Param			If-Match		header		string					true	"ETag of the shop as last fetched"
+// @Success		200				{object}	ginlib.Response			"OK"
+// @Failure		400				{object}	ginlib.Response			"Bad Request"
+// @Failure		403				{object}	ginlib.Response			"Forbidden"
+// @Failure		404				{object}	ginlib.Response			"Item not found"
+// @Failure		412				{object}	ginlib.Response			"Item was changed since it was fetched"
+// @Failure		428				{object}	ginlib.Response			"If-Match header is missing"
+// @Failure		500				{object}	ginlib.Response			"Internal Server Error"
+// @Security		ApiKeyAuth
+// @Security		UserAuth
+// @Router			/api/v1/shops/{id}/hours [put]
+func (c *shopScheduleController) UpdateHours(ctx *gin.Context) {
+	var (
+		code    = 400
+		status  = "fail"
+		message = "failed to update opening hours"
+		req     dto.ShopHoursRequest
+		err     error
+		idParam = ctx.Param("id")
+	)
+
+	defer func() {
+		ginlib.SendResponse(ctx, code, status, message, nil, err)
+	}()
+
+	versions, err := ginlib.ParseIfMatch(ctx)
+
+	if err != nil {
+		code, status = domain.GetStatus(err)
+		return
+	}
+
+	if err = ctx.ShouldBindJSON(&req); err != nil {
+		code, status = domain.GetStatus(domain.ErrBadRequest)
+		return
+	}
+
+	err = c.scheduleSvc.UpdateHours(&dto.ShopScheduleParams{
+		ShopID:    idParam,
+		Versions:  versions,
+		ActorID:   ctx.GetString("id"),
+		ActorRole: ctx.GetString("role_name"),
+	}, &req)
+	code, status = domain.GetStatus(err)
+
+	if err != nil {
+		return
+	}
+
+	message = "successfully update opening hours"
+}
+
+// @Tags			Shop Schedule (Admin and Owner)
+// @Summary		Add Shop Closure
+// @Description	Close a Shop for a whole day, like a campus holiday
+// @Accept			json
+// @Produce		json
+// @Param			ClosurePayload	body		dto.ShopClosureRequest						true	"Closure Payload"
+// @Param			id				path		string										true	"Shop ID"
+// @Success		200				{object}	ginlib.Response{data=domain.ShopClosure}	"OK"
+// @Failure		400				{object}	ginlib.Response								"Bad Request"
+// @Failure		403				{object}	ginlib.Response								"Forbidden"
+// @Failure		404				{object}	ginlib.Response								"Item not found"
+// @Failure		409				{object}	ginlib.Response								"Shop is already closed on that date"
+// @Failure		500				{object}	ginlib.Response								"Internal Server Error"
+// @Security		ApiKeyAuth
+// @Security		UserAuth
+// @Router			/api/v1/shops/{id}/closures [post]
+func (c *shopScheduleController) AddClosure(ctx *gin.Context) {
+	var (
+		code    = 400
+		status  = "fail"
+		message = "failed to add shop closure"
+		req     dto.ShopClosureRequest
+		closure *domain.ShopClosure
+		err     error
+		idParam = ctx.Param("id")
+	)
+
+	defer func() {
+		ginlib.SendResponse(ctx, code, status, message, closure, err)
+	}()
+
+	if err = ctx.ShouldBindJSON(&req); err != nil {
+		code, status = domain.GetStatus(domain.ErrBadRequest)
+		return
+	}
+
+	closure, err = c.scheduleSvc.AddClosure(&dto.ShopScheduleParams{
+		ShopID:    idParam,
+		ActorID:   ctx.GetString("id"),
+		ActorRole: ctx.GetString("role_name"),
+	}, &req)
+	code, status = domain.GetStatus(err)
+
+	if err != nil {
+		return
+	}
+
+	message = "successfully add shop closure"
+}
+
+// @Tags			Shop Schedule (Admin and Owner)
+// @Summary		Remove Shop Closure
+// @Description	Remove a Closure so the Shop follows its opening hours again on that day
+// @Produce		json
+// @Param			id			path		string			true	"Shop ID"
+// @Param			closureId	path		string			true	"Closure ID"
+// @Success		200			{object}	ginlib.Response	"OK"
+// @Failure		400			{object}	ginlib.Response	"Bad Request"
+// @Failure		403			{object}	ginlib.Response	"Forbidden"
+// @Failure		404			{object}	ginlib.Response	"Item not found"
+// @Failure		500			{object}	ginlib.Response	"Internal Server Error"
+// @Security		ApiKeyAuth
+// @Security		UserAuth
+// @Router			/api/v1/shops/{id}/closures/{closureId} [delete]
+func (c *shopScheduleController) RemoveClosure(ctx *gin.Context) {
+	var (
+		code    = 400
+		status  = "fail"
+		message = "failed to remove shop closure"
+		err     error
+		idParam = ctx.Param("id")
+	)
+
+	defer func() {
+		ginlib.SendResponse(ctx, code, status, message, nil, err)
+	}()
+
+	err = c.scheduleSvc.RemoveClosure(&dto.ShopScheduleParams{
+		ShopID:    idParam,
+		ClosureID: ctx.Param("closureId"),
+		ActorID:   ctx.GetString("id"),
+		ActorRole: ctx.GetString("role_name"),
+	})
+	code, status = domain.GetStatus(err)
+
+	if err != nil {
+		return
+	}
+
+	message = "successfully remove shop closure"
+}
+
+// @Tags			Shop Schedule (Admin and Owner)
+// @Summary		Update Shop Open Status
+// @Description	Close a Shop right now regardless of its opening hours, or reopen it. Pre-orders for later days still follow the opening hours
+// @Accept			json
+// @Produce		json
+// @Param			StatusPayload	body		dto.ShopStatusRequest	true	"Shop Status Payload"
+// @Param			id				path		string					true	"Shop ID"
+// @Param			If-Match		header		string					true	"ETag of the shop as last fetched"
+// @Success		200				{object}	ginlib.Response			"OK"
+// @Failure		400				{object}	ginlib.Response			"Bad Request"
+// @Failure		403				{object}	ginlib.Response			"Forbidden"
+// @Failure		404				{object}	ginlib.Response			"Item not found"
+// @Failure		412				{object}	ginlib.Response			"Item was changed since it was fetched"
+// @Failure		428				{object}	ginlib.Response			"If-Match header is missing"
+// @Failure		500				{object}	ginlib.Response			"Internal Server Error"
+// @Security		ApiKeyAuth
+// @Security		UserAuth
+// @Router			/api/v1/shops/{id}/status [put]
+func (c *shopScheduleController) UpdateStatus(ctx *gin.Context) {
+	var (
+		code    = 400
+		status  = "fail"
+		message = "failed to update shop status"
+		req     dto.ShopStatusRequest
+		err     error
+		idParam = ctx.Param("id")
+	)
+
+	defer func() {
+		ginlib.SendResponse(ctx, code, status, message, nil, err)
+	}()
+
+	versions, err := ginlib.ParseIfMatch(ctx)
+
+	if err != nil {
+		code, status = domain.GetStatus(err)
+		return
+	}
+
+	if err = ctx.ShouldBindJSON(&req); err != nil {
+		code, status = domain.GetStatus(domain.ErrBadRequest)
+		return
+	}
+
+	err = c.scheduleSvc.UpdateStatus(&dto.ShopScheduleParams{
+		ShopID:    idParam,
+		Versions:  versions,
+		ActorID:   ctx.GetString("id"),
+		ActorRole: ctx.GetString("role_name"),
+	}, &req)
+	code, status = domain.GetStatus(err)
+
+	if err != nil {
+		return
+	}
+
+	message = "successfully update shop status"
+}
@@ -0,0 +1,238 @@
+package repository
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/devanfer02/filkom-canteen/domain"
+	"github.com/devanfer02/filkom-canteen/internal/dto"
+	"github.com/devanfer02/filkom-canteen/internal/pkg/log"
+)
+
+const MENU_CATEGORY_TABLENAME = "menu_categories"
+
+var menuCategoryColumns = []string{
+	"category_id",
+	"shop_id",
+	"category_name",
+	"display_order",
+	"created_at",
+	"updated_at",
+}
+
+type IMenuCategoryRepository interface {
+	FetchByShop(params *dto.MenuCategoryParams) ([]domain.MenuCategory, error)
+	FetchByID(params *dto.MenuCategoryParams) (*domain.MenuCategory, error)
+	InsertCategory(category *domain.MenuCategory) error
+	UpdateCategory(params *dto.MenuCategoryParams, category *domain.MenuCategory) error
+	DeleteCategory(params *dto.MenuCategoryParams) error
+}
+
+type menuCategoryRepositoryImpl struct {
+	conn *sqlx.DB
+}
+
+func NewMenuCategoryRepository(conn *sqlx.DB) IMenuCategoryRepository {
+	return &menuCategoryRepositoryImpl{conn}
+}
+
+func (r *menuCategoryRepositoryImpl) FetchByShop(params *dto.MenuCategoryParams) ([]domain.MenuCategory, error) {
+	var (
+		qb         sq.SelectBuilder
+		query      string
+		args       []interface{}
+		categories []domain.MenuCategory = make([]domain.MenuCategory, 0)
+		err        error
+	)
+
+	qb = sq.Select(menuCategoryColumns...).
+		From(MENU_CATEGORY_TABLENAME).
+		Where("shop_id = ?", params.ShopID).
+		OrderBy("display_order ASC", "category_name ASC")
+
+	query, args, err = qb.PlaceholderFormat(sq.Dollar).ToSql()
+
+	if err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[MENU CATEGORY REPOSITORY][FetchByShop] failed to convert query builder to sql")
+		return nil, err
+	}
+
+	if err = r.conn.Select(&categories, query, args...); err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[MENU CATEGORY REPOSITORY][FetchByShop] failed to fetch menu categories")
+		return nil, err
+	}
+
+	return categories, nil
+}
+
+func (r *menuCategoryRepositoryImpl) FetchByID(params *dto.MenuCategoryParams) (*domain.MenuCategory, error) {
+	var (
+		qb       sq.SelectBuilder
+		query    string
+		args     []interface{}
+		category domain.MenuCategory
+		err      error
+	)
+
+	qb = sq.Select(menuCategoryColumns...).
+		From(MENU_CATEGORY_TABLENAME).
+		Where("category_id = ?", params.ID).
+		Limit(1)
+
+	if params.ShopID != "" {
+		qb = qb.Where("shop_id = ?", params.ShopID)
+	}
+
+	query, args, err = qb.PlaceholderFormat(sq.Dollar).ToSql()
+
+	if err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[MENU CATEGORY REPOSITORY][FetchByID] failed to convert query builder to sql")
+		return nil, err
+	}
+
+	if err = r.conn.Get(&category, query, args...); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[MENU CATEGORY REPOSITORY][FetchByID] failed to fetch menu category by id")
+		return nil, err
+	}
+
+	return &category, nil
+}
+
+func (r *menuCategoryRepositoryImpl) InsertCategory(category *domain.MenuCategory) error {
+	var (
+		qbi   sq.InsertBuilder
+		query string
+		err   error
+		args  []any
+	)
+
+	qbi = sq.
+		Insert(MENU_CATEGORY_TABLENAME).
+		Columns("shop_id", "category_name", "display_order").
+		Values(category.ShopID, category.Name, category.DisplayOrder)
+
+	query, args, err = qbi.PlaceholderFormat(sq.Dollar).ToSql()
+
+	if err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[MENU CATEGORY REPOSITORY][InsertCategory] failed to convert query builder to sql")
+		return err
+	}
+
+	if _, err = r.conn.Exec(query, args...); err != nil {
+		if strings.Contains(err.Error(), "unique constraint") {
+			return domain.ErrDuplicateEntry
+		}
+
+		if strings.Contains(err.Error(), "violates") {
+			return domain.ErrBadRequest
+		}
+
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[MENU CATEGORY REPOSITORY][InsertCategory] failed to execute sql statement")
+		return err
+	}
+
+	return nil
+}
+
+func (r *menuCategoryRepositoryImpl) UpdateCategory(params *dto.MenuCategoryParams, category *domain.MenuCategory) error {
+	var (
+		qb    sq.UpdateBuilder
+		query string
+		err   error
+		args  []any
+	)
+
+	qb = sq.
+		Update(MENU_CATEGORY_TABLENAME).
+		Set("category_name", category.Name).
+		Set("display_order", category.DisplayOrder).
+		Set("updated_at", time.Now()).
+		Where("category_id = ? AND shop_id = ?", params.ID, params.ShopID)
+
+	query, args, err = qb.PlaceholderFormat(sq.Dollar).ToSql()
+
+	if err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[MENU CATEGORY REPOSITORY][UpdateCategory] failed to convert query builder to sql")
+		return err
+	}
+
+	res, err := r.conn.Exec(query, args...)
+
+	if err != nil {
+		if strings.Contains(err.Error(), "unique constraint") {
+			return domain.ErrDuplicateEntry
+		}
+
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[MENU CATEGORY REPOSITORY][UpdateCategory] failed to execute sql statement")
+		return err
+	}
+
+	if rows, _ := res.RowsAffected(); rows < 1 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// DeleteCategory removes a category, its menus are kept and become
+// uncategorized through the ON DELETE SET NULL foreign key.
+func (r *menuCategoryRepositoryImpl) DeleteCategory(params *dto.MenuCategoryParams) error {
+	var (
+		qb    sq.DeleteBuilder
+		query string
+		err   error
+		args  []any
+	)
+
+	qb = sq.
+		Delete(MENU_CATEGORY_TABLENAME).
+		Where("category_id = ? AND shop_id = ?", params.ID, params.ShopID)
+
+	query, args, err = qb.PlaceholderFormat(sq.Dollar).ToSql()
+
+	if err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[MENU CATEGORY REPOSITORY][DeleteCategory] failed to convert query builder to sql")
+		return err
+	}
+
+	res, err := r.conn.Exec(query, args...)
+
+	if err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[MENU CATEGORY REPOSITORY][DeleteCategory] failed to execute sql statement")
+		return err
+	}
+
+	if rows, _ := res.RowsAffected(); rows < 1 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
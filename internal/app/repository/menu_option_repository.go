@@ -0,0 +1,220 @@
+package repository
+
+import (
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/devanfer02/filkom-canteen/domain"
+	"github.com/devanfer02/filkom-canteen/internal/dto"
+	"github.com/devanfer02/filkom-canteen/internal/pkg/log"
+)
+
+const (
+	MENU_OPTION_GROUP_TABLENAME = "menu_option_groups"
+	MENU_OPTION_VALUE_TABLENAME = "menu_option_values"
+)
+
+type IMenuOptionRepository interface {
+	FetchByMenu(params *dto.MenuParams) ([]domain.MenuOptionGroup, error)
+	ReplaceOptions(params *dto.MenuParams, groups []domain.MenuOptionGroup) error
+}
+
+type menuOptionRepositoryImpl struct {
+	conn *sqlx.DB
+}
+
+func NewMenuOptionRepository(conn *sqlx.DB) IMenuOptionRepository {
+	return &menuOptionRepositoryImpl{conn}
+}
+
+func (r *menuOptionRepositoryImpl) FetchByMenu(params *dto.MenuParams) ([]domain.MenuOptionGroup, error) {
+	var (
+		qb     sq.SelectBuilder
+		query  string
+		args   []interface{}
+		groups []domain.MenuOptionGroup = make([]domain.MenuOptionGroup, 0)
+		values []domain.MenuOptionValue = make([]domain.MenuOptionValue, 0)
+		err    error
+	)
+
+	qb = sq.Select(
+		"option_group_id",
+		"menu_id",
+		"group_name",
+		"min_select",
+		"max_select",
+		"is_required",
+		"display_order",
+	).From(MENU_OPTION_GROUP_TABLENAME).
+		Where("menu_id = ?", params.ID).
+		OrderBy("display_order ASC", "option_group_id ASC")
+
+	query, args, err = qb.PlaceholderFormat(sq.Dollar).ToSql()
+
+	if err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[MENU OPTION REPOSITORY][FetchByMenu] failed to convert query builder to sql")
+		return nil, err
+	}
+
+	if err = r.conn.Select(&groups, query, args...); err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[MENU OPTION REPOSITORY][FetchByMenu] failed to fetch option groups")
+		return nil, err
+	}
+
+	if len(groups) == 0 {
+		return groups, nil
+	}
+
+	qb = sq.Select(
+		"menu_option_values.option_value_id AS option_value_id",
+		"menu_option_values.option_group_id AS option_group_id",
+		"menu_option_values.value_name AS value_name",
+		"menu_option_values.price_delta AS price_delta",
+		"menu_option_values.is_available AS is_available",
+		"menu_option_values.display_order AS display_order",
+	).From(MENU_OPTION_VALUE_TABLENAME).
+		Join("menu_option_groups ON menu_option_groups.option_group_id = menu_option_values.option_group_id").
+		Where("menu_option_groups.menu_id = ?", params.ID).
+		OrderBy("menu_option_values.display_order ASC", "menu_option_values.option_value_id ASC")
+
+	query, args, err = qb.PlaceholderFormat(sq.Dollar).ToSql()
+
+	if err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[MENU OPTION REPOSITORY][FetchByMenu] failed to convert values query builder to sql")
+		return nil, err
+	}
+
+	if err = r.conn.Select(&values, query, args...); err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[MENU OPTION REPOSITORY][FetchByMenu] failed to fetch option values")
+		return nil, err
+	}
+
+	positions := make(map[string]int, len(groups))
+
+	for idx := range groups {
+		positions[groups[idx].ID] = idx
+		groups[idx].Values = make([]domain.MenuOptionValue, 0)
+	}
+
+	for _, value := range values {
+		idx := positions[value.GroupID]
+		groups[idx].Values = append(groups[idx].Values, value)
+	}
+
+	return groups, nil
+}
+
+// ReplaceOptions swaps every option group of a menu in one transaction, so a
+// student never sees a half edited set of options.
+func (r *menuOptionRepositoryImpl) ReplaceOptions(params *dto.MenuParams, groups []domain.MenuOptionGroup) error {
+	var (
+		qbd   sq.DeleteBuilder
+		qbi   sq.InsertBuilder
+		query string
+		err   error
+		args  []any
+		tx    *sqlx.Tx
+	)
+
+	tx, err = r.conn.Beginx()
+
+	if err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[MENU OPTION REPOSITORY][ReplaceOptions] failed to begin transaction")
+		return err
+	}
+
+	defer tx.Rollback()
+
+	qbd = sq.
+		Delete(MENU_OPTION_GROUP_TABLENAME).
+		Where("menu_id = ?", params.ID)
+
+	query, args, err = qbd.PlaceholderFormat(sq.Dollar).ToSql()
+
+	if err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[MENU OPTION REPOSITORY][ReplaceOptions] failed to convert delete query builder to sql")
+		return err
+	}
+
+	if _, err = tx.Exec(query, args...); err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[MENU OPTION REPOSITORY][ReplaceOptions] failed to delete option groups")
+		return err
+	}
+
+	for idx := range groups {
+		group := &groups[idx]
+
+		qbi = sq.
+			Insert(MENU_OPTION_GROUP_TABLENAME).
+			Columns("menu_id", "group_name", "min_select", "max_select", "is_required", "display_order").
+			Values(params.ID, group.Name, group.MinSelect, group.MaxSelect, group.Required, group.DisplayOrder).
+			Suffix("RETURNING option_group_id")
+
+		query, args, err = qbi.PlaceholderFormat(sq.Dollar).ToSql()
+
+		if err != nil {
+			log.Error(log.LogInfo{
+				"error": err.Error(),
+			}, "[MENU OPTION REPOSITORY][ReplaceOptions] failed to convert group query builder to sql")
+			return err
+		}
+
+		if err = tx.Get(&group.ID, query, args...); err != nil {
+			log.Error(log.LogInfo{
+				"error": err.Error(),
+			}, "[MENU OPTION REPOSITORY][ReplaceOptions] failed to insert option group")
+			return err
+		}
+
+		qbi = sq.
+			Insert(MENU_OPTION_VALUE_TABLENAME).
+			Columns("option_group_id", "value_name", "price_delta", "is_available", "display_order")
+
+		for _, value := range group.Values {
+			qbi = qbi.Values(group.ID, value.Name, value.PriceDelta, value.Available, value.DisplayOrder)
+		}
+
+		query, args, err = qbi.PlaceholderFormat(sq.Dollar).ToSql()
+
+		if err != nil {
+			log.Error(log.LogInfo{
+				"error": err.Error(),
+			}, "[MENU OPTION REPOSITORY][ReplaceOptions] failed to convert values query builder to sql")
+			return err
+		}
+
+		if _, err = tx.Exec(query, args...); err != nil {
+			log.Error(log.LogInfo{
+				"error": err.Error(),
+			}, "[MENU OPTION REPOSITORY][ReplaceOptions] failed to insert option values")
+			return err
+		}
+	}
+
+	if err = bumpVersion(tx, MENU_TABLENAME, "menu_id", params.ID, params.Versions); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[MENU OPTION REPOSITORY][ReplaceOptions] failed to commit transaction")
+		return err
+	}
+
+	return nil
+}
@@ -10,17 +10,33 @@ import (
 
 	"github.com/devanfer02/filkom-canteen/domain"
 	"github.com/devanfer02/filkom-canteen/internal/dto"
+	"github.com/devanfer02/filkom-canteen/internal/pkg/clock"
 	"github.com/devanfer02/filkom-canteen/internal/pkg/log"
 )
 
 const MENU_TABLENAME = "menus"
 
+// menuSorts whitelists the sort options of FetchAll, an empty column sorts
+// by the time ordered id alone.
+var menuSorts = map[string]struct {
+	column string
+	desc   bool
+}{
+	dto.MenuSortNewest:    {"", true},
+	dto.MenuSortPriceAsc:  {"COALESCE(menus.menu_price, 0)", false},
+	dto.MenuSortPriceDesc: {"COALESCE(menus.menu_price, 0)", true},
+	dto.MenuSortNameAsc:   {"COALESCE(menus.menu_name, '')", false},
+	dto.MenuSortNameDesc:  {"COALESCE(menus.menu_name, '')", true},
+	dto.MenuSortPopular:   {"COALESCE(sales.sold, 0)", true},
+}
+
 type IMenuRepository interface {
 	FetchAll(params *dto.MenuParams) ([]domain.Menu, error)
 	FetchByID(params *dto.MenuParams) (*domain.Menu, error)
 	InsertMenu(owner *domain.Menu) error
 	UpdateMenu(params *dto.MenuParams, owner *domain.Menu) error
 	DeleteMenu(params *dto.MenuParams) error
+	ResetDailyStock(date string) (int64, error)
 }
 
 type menuRepositoryImpl struct {
@@ -40,13 +56,23 @@ func (r *menuRepositoryImpl) FetchAll(params *dto.MenuParams) ([]domain.Menu, er
 		err   error
 	)
 
+	sort, ok := menuSorts[params.Filter.Sort]
+
+	if !ok {
+		sort = menuSorts[dto.MenuSortNewest]
+	}
+
 	qb = sq.Select(
 		"menu_id",
 		"menu_name",
 		"menus.shop_id AS menu_shop_id",
+		"COALESCE(menus.category_id::text, '') AS menu_category_id",
 		"menu_price",
 		"menu_status",
+		"menus.daily_stock AS daily_stock",
+		"menus.stock_remaining AS stock_remaining",
 		"menu_photo_link",
+		"menus.version AS version",
 		"menus.created_at AS created_at",
 		"menus.updated_at AS updated_at",
 	).From(MENU_TABLENAME)
@@ -57,6 +83,40 @@ func (r *menuRepositoryImpl) FetchAll(params *dto.MenuParams) ([]domain.Menu, er
 			Where("shops.shop_id = ?", params.ShopID)
 	}
 
+	if params.Filter.MinPrice != nil {
+		qb = qb.Where("menus.menu_price >= ?", *params.Filter.MinPrice)
+	}
+
+	if params.Filter.MaxPrice != nil {
+		qb = qb.Where("menus.menu_price <= ?", *params.Filter.MaxPrice)
+	}
+
+	if params.Filter.Status != "" {
+		qb = qb.Where("menus.menu_status = ?", params.Filter.Status)
+	}
+
+	if params.Filter.Search != "" {
+		qb = qb.Where("menus.menu_name ILIKE ?", "%"+escapeLike(params.Filter.Search)+"%")
+	}
+
+	if params.Filter.Sort == dto.MenuSortPopular {
+		qb = qb.
+			Column("COALESCE(sales.sold, 0) AS sold").
+			LeftJoin(`(
+				SELECT order_items.menu_id, SUM(order_items.quantity) AS sold
+				FROM order_items
+				JOIN orders ON orders.order_id = order_items.order_id
+				WHERE orders.status NOT IN (?, ?)
+				GROUP BY order_items.menu_id
+			) sales ON sales.menu_id = menus.menu_id`, domain.OrderStatusCancelled, domain.OrderStatusRejected)
+	}
+
+	if sort.column == "" {
+		qb = paginate(qb, "menus.menu_id", &params.Page)
+	} else {
+		qb = paginateBy(qb, sort.column, "menus.menu_id", sort.desc, &params.Page)
+	}
+
 	query, args, err = qb.PlaceholderFormat(sq.Dollar).ToSql()
 
 	if err != nil {
@@ -91,9 +151,13 @@ func (r *menuRepositoryImpl) FetchByID(params *dto.MenuParams) (*domain.Menu, er
 		"menu_id",
 		"menu_name",
 		"menus.shop_id AS menu_shop_id",
+		"COALESCE(menus.category_id::text, '') AS menu_category_id",
 		"menu_price",
 		"menu_status",
+		"daily_stock",
+		"stock_remaining",
 		"menu_photo_link",
+		"version",
 		"created_at",
 		"updated_at",
 	).From(MENU_TABLENAME).
@@ -134,8 +198,14 @@ func (r *menuRepositoryImpl) InsertMenu(menu *domain.Menu) error {
 
 	qbi = sq.
 		Insert(MENU_TABLENAME).
-		Columns("menu_name", "shop_id", "menu_price", "menu_status", "menu_photo_link").
-		Values(menu.Name, menu.ShopID, menu.Price, menu.Status, menu.PhotoLink)
+		Columns(
+			"menu_name", "shop_id", "category_id", "menu_price", "menu_status", "menu_photo_link",
+			"daily_stock", "stock_remaining", "stock_reset_on",
+		).
+		Values(
+			menu.Name, menu.ShopID, nullable(menu.CategoryID), menu.Price, menu.Status, menu.PhotoLink,
+			menu.DailyStock, menu.Stock, stockResetOn(menu),
+		)
 
 	query, args, err = qbi.PlaceholderFormat(sq.Dollar).ToSql()
 
@@ -172,12 +242,22 @@ func (r *menuRepositoryImpl) UpdateMenu(params *dto.MenuParams, menu *domain.Men
 	qb = sq.
 		Update(MENU_TABLENAME).
 		Set("menu_name", menu.Name).
+		Set("category_id", nullable(menu.CategoryID)).
 		Set("menu_price", menu.Price).
 		Set("menu_photo_link", menu.PhotoLink).
 		Set("menu_status", menu.Status).
+		Set("daily_stock", menu.DailyStock).
+		Set("stock_remaining", menu.Stock).
+		Set("stock_reset_on", stockResetOn(menu)).
+		Set("version", sq.Expr("version + 1")).
 		Set("updated_at", time.Now()).
 		Where("menu_id = ?", params.ID)
 
+	// only overwrites the versions the caller has seen
+	if len(params.Versions) > 0 {
+		qb = qb.Where(sq.Eq{"version": params.Versions})
+	}
+
 	query, args, err = qb.PlaceholderFormat(sq.Dollar).ToSql()
 
 	if err != nil {
@@ -240,3 +320,60 @@ func (r *menuRepositoryImpl) DeleteMenu(params *dto.MenuParams) error {
 
 	return nil
 }
+
+// ResetDailyStock refills every stock tracked menu that has not been reset on
+// the given date yet, so running it twice a day is harmless. Menus that were
+// sold out by running out of stock become available again.
+func (r *menuRepositoryImpl) ResetDailyStock(date string) (int64, error) {
+	var (
+		qb    sq.UpdateBuilder
+		query string
+		err   error
+		args  []any
+	)
+
+	qb = sq.
+		Update(MENU_TABLENAME).
+		Set("stock_remaining", sq.Expr("daily_stock")).
+		Set("menu_status", sq.Expr(
+			"CASE WHEN daily_stock = 0 THEN ?::MENU_STATUS_ENUM WHEN COALESCE(stock_remaining, 0) = 0 THEN ?::MENU_STATUS_ENUM ELSE menu_status END",
+			domain.MenuStatusSoldOut, domain.MenuStatusAvailable,
+		)).
+		Set("stock_reset_on", date).
+		Set("version", sq.Expr("version + 1")).
+		Set("updated_at", time.Now()).
+		Where("daily_stock IS NOT NULL").
+		Where("(stock_reset_on IS NULL OR stock_reset_on < ?)", date)
+
+	query, args, err = qb.PlaceholderFormat(sq.Dollar).ToSql()
+
+	if err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[MENU REPOSITORY][ResetDailyStock] failed to convert query builder to sql")
+		return 0, err
+	}
+
+	res, err := r.conn.Exec(query, args...)
+
+	if err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[MENU REPOSITORY][ResetDailyStock] failed to execute sql statement")
+		return 0, err
+	}
+
+	rows, _ := res.RowsAffected()
+
+	return rows, nil
+}
+
+// stockResetOn marks a stock tracked menu as already stocked for today, so the
+// daily reset does not overwrite stock an owner just set.
+func stockResetOn(menu *domain.Menu) any {
+	if menu.DailyStock == nil {
+		return nil
+	}
+
+	return clock.Today()
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"database/sql"
+	"strings"
 	"time"
 
 	sq "github.com/Masterminds/squirrel"
@@ -12,14 +13,47 @@ import (
 	"github.com/devanfer02/filkom-canteen/internal/pkg/log"
 )
 
-const ORDER_TABLENAME = "orders"
+const (
+	ORDER_TABLENAME             = "orders"
+	ORDER_ITEM_TABLENAME        = "order_items"
+	ORDER_ITEM_OPTION_TABLENAME = "order_item_options"
+	ORDER_HISTORY_TABLENAME     = "order_status_history"
+	QUEUE_COUNTER_TABLENAME     = "shop_queue_counters"
+)
+
+var orderColumns = []string{
+	"orders.order_id AS order_id",
+	"orders.user_id AS order_user_id",
+	"COALESCE(orders.shop_id::text, '') AS order_shop_id",
+	"orders.payment_method AS payment_method",
+	"COALESCE(orders.payment_proof_link, '') AS payment_proof_link",
+	"orders.payment_status AS payment_status",
+	"COALESCE(orders.payment_note, '') AS payment_note",
+	"COALESCE(orders.queue_number, 0) AS queue_number",
+	"COALESCE(orders.queue_date::text, '') AS queue_date",
+	"orders.status AS status",
+	"orders.pickup_at AS pickup_at",
+	"orders.cancelled_at AS cancelled_at",
+	"COALESCE(orders.cancelled_by::text, '') AS cancelled_by",
+	"COALESCE(orders.cancelled_by_type, '') AS cancelled_by_type",
+	"COALESCE(orders.cancel_reason, '') AS cancel_reason",
+	"orders.version AS version",
+	"COALESCE((SELECT SUM(order_items.quantity * order_items.unit_price) FROM order_items WHERE order_items.order_id = orders.order_id), 0) AS total",
+	"orders.created_at AS created_at",
+	"orders.updated_at AS updated_at",
+}
 
 type IOrderRepository interface {
 	FetchAll(params *dto.OrderParams) ([]domain.Order, error)
 	FetchByID(params *dto.OrderParams) (*domain.Order, error)
-	InsertOrder(owner *domain.Order) error
-	UpdateOrder(params *dto.OrderParams, owner *domain.Order) error
-	DeleteOrder(params *dto.OrderParams) error
+	FetchHistory(params *dto.OrderParams) ([]domain.OrderStatusHistory, error)
+	FetchByPickup(params *dto.OrderParams) ([]domain.Order, error)
+	FetchBoard(params *dto.OrderParams) ([]domain.BoardShop, error)
+	InsertOrder(order *domain.Order, history *domain.OrderStatusHistory, slotCapacity int) error
+	UpdateOrder(params *dto.OrderParams, order *domain.Order, history *domain.OrderStatusHistory) error
+	UpdatePaymentProof(params *dto.OrderParams, order *domain.Order) error
+	VerifyPayment(params *dto.OrderParams, order *domain.Order, history *domain.OrderStatusHistory) error
+	CancelOrder(params *dto.OrderParams, order *domain.Order, history *domain.OrderStatusHistory) error
 }
 
 type orderRepositoryImpl struct {
@@ -39,30 +73,28 @@ func (r *orderRepositoryImpl) FetchAll(params *dto.OrderParams) ([]domain.Order,
 		err    error
 	)
 
-	qb = sq.Select(
-		"order_id",
-		"orders.user_id AS order_user_id",
-		"orders.menu_id AS order_menu_id",
-		"payment_method",
-		"payment_proof_link",
-		"status",
-		"orders.created_at AS created_at",
-		"orders.updated_at AS updated_at",
-	).From(ORDER_TABLENAME)
+	qb = sq.Select(orderColumns...).From(ORDER_TABLENAME)
 
 	if params.ShopID != "" {
-		qb = qb.
-			Join("menus ON menus.menu_id = orders.menu_id").
-			Join("shops ON shops.shop_id = menus.shop_id").
-			Where("shops.shop_id = ?", params.ShopID)
+		qb = qb.Where("orders.shop_id = ?", params.ShopID)
 	}
 
 	if params.UserID != "" {
-		qb = qb. 
-			Join("users ON users.user_id = orders.user_id"). 
-			Where("users.user_id = ?", params.UserID)
+		qb = qb.Where("orders.user_id = ?", params.UserID)
+	}
+
+	if params.OwnerID != "" {
+		qb = qb.
+			Join("shop_owners ON shop_owners.shop_id = orders.shop_id").
+			Where("shop_owners.admin_id = ?", params.OwnerID)
+	}
+
+	if params.Status != "" {
+		qb = qb.Where("orders.status = ?", params.Status)
 	}
 
+	qb = paginate(qb, "orders.order_id", &params.Page)
+
 	query, args, err = qb.PlaceholderFormat(sq.Dollar).ToSql()
 
 	if err != nil {
@@ -92,9 +124,9 @@ func (r *orderRepositoryImpl) FetchByID(params *dto.OrderParams) (*domain.Order,
 		err   error
 	)
 
-	qb = sq.Select("*").
+	qb = sq.Select(orderColumns...).
 		From(ORDER_TABLENAME).
-		Where("order_id = ?", params.ID).
+		Where("orders.order_id = ?", params.ID).
 		Limit(1)
 
 	query, args, err = qb.PlaceholderFormat(sq.Dollar).ToSql()
@@ -118,21 +150,316 @@ func (r *orderRepositoryImpl) FetchByID(params *dto.OrderParams) (*domain.Order,
 		return nil, err
 	}
 
+	order.Items, err = r.fetchItems(order.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
 	return &order, nil
 }
 
-func (r *orderRepositoryImpl) InsertOrder(order *domain.Order) error {
+// FetchByPickup lists the active orders of a shop with a pickup time in the
+// given range, in pickup order and with their items.
+func (r *orderRepositoryImpl) FetchByPickup(params *dto.OrderParams) ([]domain.Order, error) {
+	var (
+		qb     sq.SelectBuilder
+		query  string
+		args   []interface{}
+		orders []domain.Order = make([]domain.Order, 0)
+		err    error
+	)
+
+	qb = sq.Select(orderColumns...).
+		From(ORDER_TABLENAME).
+		Where("orders.shop_id = ?", params.ShopID).
+		Where("orders.pickup_at >= ? AND orders.pickup_at < ?", params.PickupFrom, params.PickupTo).
+		Where(sq.Eq{"orders.status": []string{
+			domain.OrderStatusWaiting,
+			domain.OrderStatusAccepted,
+			domain.OrderStatusPreparing,
+			domain.OrderStatusReady,
+		}}).
+		OrderBy("orders.pickup_at ASC", "orders.order_id ASC")
+
+	query, args, err = qb.PlaceholderFormat(sq.Dollar).ToSql()
+
+	if err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[ORDER REPOSITORY][FetchByPickup] failed to convert query builder to sql")
+		return nil, err
+	}
+
+	if err = r.conn.Select(&orders, query, args...); err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[ORDER REPOSITORY][FetchByPickup] failed to fetch orders")
+		return nil, err
+	}
+
+	if len(orders) == 0 {
+		return orders, nil
+	}
+
+	orderIDs := make([]string, len(orders))
+	positions := make(map[string]int, len(orders))
+
+	for idx := range orders {
+		orderIDs[idx] = orders[idx].ID
+		positions[orders[idx].ID] = idx
+	}
+
+	items, err := r.fetchItems(orderIDs...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range items {
+		idx := positions[item.OrderID]
+		orders[idx].Items = append(orders[idx].Items, item)
+	}
+
+	return orders, nil
+}
+
+// FetchBoard lists every shop, or only params.ShopID, with the queue numbers
+// of its orders on params.QueueDate that are still shown on the board.
+func (r *orderRepositoryImpl) FetchBoard(params *dto.OrderParams) ([]domain.BoardShop, error) {
+	type boardRow struct {
+		domain.BoardEntry
+		ShopID   string `db:"shop_id"`
+		ShopName string `db:"shop_name"`
+	}
+
+	var (
+		qb    sq.SelectBuilder
+		query string
+		args  []interface{}
+		rows  []boardRow
+		shops []domain.BoardShop = make([]domain.BoardShop, 0)
+		err   error
+	)
+
+	statuses := sq.Eq{"orders.status": domain.BoardStatuses}
+	statusSql, statusArgs, _ := statuses.ToSql()
+
+	qb = sq.Select(
+		"shops.shop_id AS shop_id",
+		"shops.shop_name AS shop_name",
+		"COALESCE(orders.queue_number, 0) AS queue_number",
+		"COALESCE(orders.status, '') AS status",
+	).
+		From(SHOP_TABLENAME).
+		LeftJoin(
+			ORDER_TABLENAME+" ON orders.shop_id = shops.shop_id AND orders.queue_date = ? AND orders.queue_number IS NOT NULL AND "+statusSql,
+			append([]interface{}{params.QueueDate}, statusArgs...)...,
+		).
+		OrderBy("shops.shop_name ASC", "shops.shop_id ASC", "orders.queue_number ASC")
+
+	if params.ShopID != "" {
+		qb = qb.Where("shops.shop_id = ?", params.ShopID)
+	}
+
+	query, args, err = qb.PlaceholderFormat(sq.Dollar).ToSql()
+
+	if err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[ORDER REPOSITORY][FetchBoard] failed to convert query builder to sql")
+		return nil, err
+	}
+
+	if err = r.conn.Select(&rows, query, args...); err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[ORDER REPOSITORY][FetchBoard] failed to fetch board")
+		return nil, err
+	}
+
+	for _, row := range rows {
+		if len(shops) == 0 || shops[len(shops)-1].ShopID != row.ShopID {
+			shops = append(shops, domain.BoardShop{
+				ShopID:   row.ShopID,
+				ShopName: row.ShopName,
+				Orders:   make([]domain.BoardEntry, 0),
+			})
+		}
+
+		// shops without any order on the board come back as a single empty row
+		if row.QueueNumber != 0 {
+			current := &shops[len(shops)-1]
+			current.Orders = append(current.Orders, row.BoardEntry)
+		}
+	}
+
+	if params.ShopID != "" && len(shops) == 0 {
+		return nil, domain.ErrNotFound
+	}
+
+	return shops, nil
+}
+
+func (r *orderRepositoryImpl) fetchItems(orderIDs ...string) ([]domain.OrderItem, error) {
+	var (
+		qb      sq.SelectBuilder
+		query   string
+		args    []interface{}
+		items   []domain.OrderItem       = make([]domain.OrderItem, 0)
+		options []domain.OrderItemOption = make([]domain.OrderItemOption, 0)
+		err     error
+	)
+
+	qb = sq.Select(
+		"order_item_id",
+		"order_id",
+		"COALESCE(menu_id::text, '') AS menu_id",
+		"COALESCE(menu_name, '') AS menu_name",
+		"quantity",
+		"unit_price",
+		"quantity * unit_price AS subtotal",
+		"COALESCE(notes, '') AS notes",
+	).From(ORDER_ITEM_TABLENAME).
+		Where(sq.Eq{"order_id": orderIDs}).
+		OrderBy("order_item_id")
+
+	query, args, err = qb.PlaceholderFormat(sq.Dollar).ToSql()
+
+	if err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[ORDER REPOSITORY][fetchItems] failed to convert query builder to sql")
+		return nil, err
+	}
+
+	if err = r.conn.Select(&items, query, args...); err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[ORDER REPOSITORY][fetchItems] failed to fetch order items")
+		return nil, err
+	}
+
+	qb = sq.Select(
+		"order_item_options.order_item_option_id AS order_item_option_id",
+		"order_item_options.order_item_id AS order_item_id",
+		"COALESCE(order_item_options.option_value_id::text, '') AS option_value_id",
+		"order_item_options.group_name AS group_name",
+		"order_item_options.value_name AS value_name",
+		"order_item_options.price_delta AS price_delta",
+	).From(ORDER_ITEM_OPTION_TABLENAME).
+		Join("order_items ON order_items.order_item_id = order_item_options.order_item_id").
+		Where(sq.Eq{"order_items.order_id": orderIDs}).
+		OrderBy("order_item_options.order_item_option_id")
+
+	query, args, err = qb.PlaceholderFormat(sq.Dollar).ToSql()
+
+	if err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[ORDER REPOSITORY][fetchItems] failed to convert options query builder to sql")
+		return nil, err
+	}
+
+	if err = r.conn.Select(&options, query, args...); err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[ORDER REPOSITORY][fetchItems] failed to fetch order item options")
+		return nil, err
+	}
+
+	positions := make(map[string]int, len(items))
+
+	for idx := range items {
+		positions[items[idx].ID] = idx
+	}
+
+	for _, option := range options {
+		idx := positions[option.OrderItemID]
+		items[idx].Options = append(items[idx].Options, option)
+	}
+
+	return items, nil
+}
+
+func (r *orderRepositoryImpl) FetchHistory(params *dto.OrderParams) ([]domain.OrderStatusHistory, error) {
+	var (
+		qb      sq.SelectBuilder
+		query   string
+		args    []interface{}
+		history []domain.OrderStatusHistory = make([]domain.OrderStatusHistory, 0)
+		err     error
+	)
+
+	qb = sq.Select(
+		"history_id",
+		"order_id",
+		"COALESCE(actor_id::text, '') AS actor_id",
+		"COALESCE(actor_type, '') AS actor_type",
+		"COALESCE(old_status, '') AS old_status",
+		"new_status",
+		"COALESCE(reason, '') AS reason",
+		"created_at",
+	).From(ORDER_HISTORY_TABLENAME).
+		Where("order_id = ?", params.ID).
+		OrderBy("created_at", "history_id")
+
+	query, args, err = qb.PlaceholderFormat(sq.Dollar).ToSql()
+
+	if err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[ORDER REPOSITORY][FetchHistory] failed to convert query builder to sql")
+		return nil, err
+	}
+
+	if err = r.conn.Select(&history, query, args...); err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[ORDER REPOSITORY][FetchHistory] failed to fetch order status history")
+		return nil, err
+	}
+
+	return history, nil
+}
+
+// InsertOrder stores an order with its items. A slotCapacity above zero caps
+// the active orders sharing the order's pickup slot.
+func (r *orderRepositoryImpl) InsertOrder(order *domain.Order, history *domain.OrderStatusHistory, slotCapacity int) error {
 	var (
 		qbi   sq.InsertBuilder
 		query string
 		err   error
 		args  []any
+		tx    *sqlx.Tx
 	)
 
+	tx, err = r.conn.Beginx()
+
+	if err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[ORDER REPOSITORY][InsertOrder] failed to begin transaction")
+		return err
+	}
+
+	defer tx.Rollback()
+
+	if order.PickupAt != nil && slotCapacity > 0 {
+		if err = reserveSlot(tx, order, slotCapacity); err != nil {
+			return err
+		}
+	}
+
+	if err = nextQueueNumber(tx, order); err != nil {
+		return err
+	}
+
 	qbi = sq.
 		Insert(ORDER_TABLENAME).
-		Columns("user_id", "menu_id", "payment_method", "status", "payment_proof_link").
-		Values(order.UserID, order.MenuID, order.PaymentMethod, order.Status, order.PaymentProofLink)
+		Columns("user_id", "shop_id", "payment_method", "status", "payment_proof_link", "pickup_at", "queue_number", "queue_date").
+		Values(order.UserID, order.ShopID, order.PaymentMethod, order.Status, order.PaymentProofLink, order.PickupAt, order.QueueNumber, order.QueueDate).
+		Suffix("RETURNING order_id")
 
 	query, args, err = qbi.PlaceholderFormat(sq.Dollar).ToSql()
 
@@ -143,32 +470,61 @@ func (r *orderRepositoryImpl) InsertOrder(order *domain.Order) error {
 		return err
 	}
 
-	if _, err = r.conn.Exec(query, args...); err != nil {
+	if err = tx.Get(&order.ID, query, args...); err != nil {
 		log.Error(log.LogInfo{
 			"error": err.Error(),
 		}, "[ORDER REPOSITORY][InsertOrder] failed to execute sql statement")
 		return err
 	}
 
+	for idx := range order.Items {
+		if err = insertOrderItem(tx, order.ID, &order.Items[idx]); err != nil {
+			return err
+		}
+	}
+
+	history.OrderID = order.ID
+
+	if err = insertOrderHistory(tx, history); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[ORDER REPOSITORY][InsertOrder] failed to commit transaction")
+		return err
+	}
+
 	return nil
 }
 
-func (r *orderRepositoryImpl) UpdateOrder(params *dto.OrderParams, order *domain.Order) error {
+func (r *orderRepositoryImpl) UpdateOrder(params *dto.OrderParams, order *domain.Order, history *domain.OrderStatusHistory) error {
 	var (
 		qb    sq.UpdateBuilder
 		query string
 		err   error
 		args  []any
+		tx    *sqlx.Tx
 	)
 
 	qb = sq.
 		Update(ORDER_TABLENAME).
 		Set("status", order.Status).
 		Set("payment_method", order.PaymentMethod).
-		Set("payment_proof_link", order.PaymentProofLink).
+		Set("version", sq.Expr("version + 1")).
 		Set("updated_at", time.Now()).
 		Where("order_id = ?", params.ID)
 
+	// guards the update against a concurrent status change
+	if params.Status != "" {
+		qb = qb.Where("status = ?", params.Status)
+	}
+
+	if len(params.Versions) > 0 {
+		qb = qb.Where(sq.Eq{"version": params.Versions})
+	}
+
 	query, args, err = qb.PlaceholderFormat(sq.Dollar).ToSql()
 
 	if err != nil {
@@ -178,7 +534,18 @@ func (r *orderRepositoryImpl) UpdateOrder(params *dto.OrderParams, order *domain
 		return err
 	}
 
-	res, err := r.conn.Exec(query, args...)
+	tx, err = r.conn.Beginx()
+
+	if err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[ORDER REPOSITORY][UpdateOrder] failed to begin transaction")
+		return err
+	}
+
+	defer tx.Rollback()
+
+	res, err := tx.Exec(query, args...)
 
 	if err != nil {
 		log.Error(log.LogInfo{
@@ -191,27 +558,59 @@ func (r *orderRepositoryImpl) UpdateOrder(params *dto.OrderParams, order *domain
 		return domain.ErrNotFound
 	}
 
+	if order.Status == domain.OrderStatusAccepted {
+		if err = takeStock(tx, params.ID); err != nil {
+			return err
+		}
+	}
+
+	history.OrderID = params.ID
+
+	if err = insertOrderHistory(tx, history); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[ORDER REPOSITORY][UpdateOrder] failed to commit transaction")
+		return err
+	}
+
 	return nil
 }
 
-func (r *orderRepositoryImpl) DeleteOrder(params *dto.OrderParams) error {
+func (r *orderRepositoryImpl) UpdatePaymentProof(params *dto.OrderParams, order *domain.Order) error {
 	var (
-		qb    sq.DeleteBuilder
+		qb    sq.UpdateBuilder
 		query string
-		args  []interface{}
 		err   error
+		args  []any
 	)
 
 	qb = sq.
-		Delete(ORDER_TABLENAME).
+		Update(ORDER_TABLENAME).
+		Set("payment_proof_link", order.PaymentProofLink).
+		Set("payment_status", order.PaymentStatus).
+		Set("payment_note", "").
+		Set("version", sq.Expr("version + 1")).
+		Set("updated_at", time.Now()).
 		Where("order_id = ? AND user_id = ?", params.ID, params.UserID)
 
+	if params.Status != "" {
+		qb = qb.Where("status = ?", params.Status)
+	}
+
+	if params.PaymentStatus != "" {
+		qb = qb.Where("payment_status = ?", params.PaymentStatus)
+	}
+
 	query, args, err = qb.PlaceholderFormat(sq.Dollar).ToSql()
 
 	if err != nil {
 		log.Error(log.LogInfo{
 			"error": err.Error(),
-		}, "[ORDER REPOSITORY][DeleteOrder] failed to convert query builder to sql")
+		}, "[ORDER REPOSITORY][UpdatePaymentProof] failed to convert query builder to sql")
 		return err
 	}
 
@@ -220,7 +619,7 @@ func (r *orderRepositoryImpl) DeleteOrder(params *dto.OrderParams) error {
 	if err != nil {
 		log.Error(log.LogInfo{
 			"error": err.Error(),
-		}, "[ORDER REPOSITORY][DeleteOrder] failed to execute sql statement")
+		}, "[ORDER REPOSITORY][UpdatePaymentProof] failed to execute sql statement")
 		return err
 	}
 
@@ -230,3 +629,458 @@ func (r *orderRepositoryImpl) DeleteOrder(params *dto.OrderParams) error {
 
 	return nil
 }
+
+func (r *orderRepositoryImpl) VerifyPayment(params *dto.OrderParams, order *domain.Order, history *domain.OrderStatusHistory) error {
+	var (
+		qb    sq.UpdateBuilder
+		query string
+		err   error
+		args  []any
+		tx    *sqlx.Tx
+	)
+
+	qb = sq.
+		Update(ORDER_TABLENAME).
+		Set("status", order.Status).
+		Set("payment_status", order.PaymentStatus).
+		Set("payment_note", order.PaymentNote).
+		Set("payment_verified_by", params.ActorID).
+		Set("payment_verified_at", time.Now()).
+		Set("version", sq.Expr("version + 1")).
+		Set("updated_at", time.Now()).
+		Where("order_id = ?", params.ID)
+
+	if params.Status != "" {
+		qb = qb.Where("status = ?", params.Status)
+	}
+
+	if params.PaymentStatus != "" {
+		qb = qb.Where("payment_status = ?", params.PaymentStatus)
+	}
+
+	query, args, err = qb.PlaceholderFormat(sq.Dollar).ToSql()
+
+	if err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[ORDER REPOSITORY][VerifyPayment] failed to convert query builder to sql")
+		return err
+	}
+
+	tx, err = r.conn.Beginx()
+
+	if err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[ORDER REPOSITORY][VerifyPayment] failed to begin transaction")
+		return err
+	}
+
+	defer tx.Rollback()
+
+	res, err := tx.Exec(query, args...)
+
+	if err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[ORDER REPOSITORY][VerifyPayment] failed to execute sql statement")
+		return err
+	}
+
+	if rows, _ := res.RowsAffected(); rows < 1 {
+		return domain.ErrNotFound
+	}
+
+	if order.Status == domain.OrderStatusAccepted {
+		if err = takeStock(tx, params.ID); err != nil {
+			return err
+		}
+	}
+
+	// a rejected payment keeps the order status, so there is no transition to record
+	if history != nil {
+		history.OrderID = params.ID
+
+		if err = insertOrderHistory(tx, history); err != nil {
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[ORDER REPOSITORY][VerifyPayment] failed to commit transaction")
+		return err
+	}
+
+	return nil
+}
+
+// CancelOrder moves the order to Cancelled with the cancellation details of
+// order, the row itself is kept.
+func (r *orderRepositoryImpl) CancelOrder(params *dto.OrderParams, order *domain.Order, history *domain.OrderStatusHistory) error {
+	var (
+		qb    sq.UpdateBuilder
+		query string
+		err   error
+		args  []any
+		tx    *sqlx.Tx
+	)
+
+	qb = sq.
+		Update(ORDER_TABLENAME).
+		Set("status", domain.OrderStatusCancelled).
+		Set("cancelled_at", order.CancelledAt).
+		Set("cancelled_by", order.CancelledBy).
+		Set("cancelled_by_type", order.CancelledByType).
+		Set("cancel_reason", order.CancelReason).
+		Set("version", sq.Expr("version + 1")).
+		Set("updated_at", time.Now()).
+		Where("order_id = ?", params.ID).
+		// guards the cancellation against a concurrent status change
+		Where("status = ?", params.Status)
+
+	if len(params.Versions) > 0 {
+		qb = qb.Where(sq.Eq{"version": params.Versions})
+	}
+
+	query, args, err = qb.PlaceholderFormat(sq.Dollar).ToSql()
+
+	if err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[ORDER REPOSITORY][CancelOrder] failed to convert query builder to sql")
+		return err
+	}
+
+	tx, err = r.conn.Beginx()
+
+	if err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[ORDER REPOSITORY][CancelOrder] failed to begin transaction")
+		return err
+	}
+
+	defer tx.Rollback()
+
+	res, err := tx.Exec(query, args...)
+
+	if err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[ORDER REPOSITORY][CancelOrder] failed to execute sql statement")
+		return err
+	}
+
+	if rows, _ := res.RowsAffected(); rows < 1 {
+		return domain.ErrNotFound
+	}
+
+	if domain.OrderHoldsStock(params.Status) {
+		if err = returnStock(tx, params.ID); err != nil {
+			return err
+		}
+	}
+
+	history.OrderID = params.ID
+
+	if err = insertOrderHistory(tx, history); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[ORDER REPOSITORY][CancelOrder] failed to commit transaction")
+		return err
+	}
+
+	return nil
+}
+
+// reserveSlot makes sure the pickup slot of an order still has room. The
+// advisory lock is held until the transaction ends, so concurrent orders for
+// the same slot are counted one after another.
+func reserveSlot(tx *sqlx.Tx, order *domain.Order, capacity int) error {
+	var (
+		qb    sq.SelectBuilder
+		query string
+		err   error
+		args  []any
+		taken int
+	)
+
+	slot := order.ShopID + "@" + order.PickupAt.UTC().Format(time.RFC3339)
+
+	if _, err = tx.Exec("SELECT pg_advisory_xact_lock(hashtext($1))", slot); err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[ORDER REPOSITORY][reserveSlot] failed to lock pickup slot")
+		return err
+	}
+
+	qb = sq.Select("COUNT(*)").
+		From(ORDER_TABLENAME).
+		Where("shop_id = ? AND pickup_at = ?", order.ShopID, order.PickupAt).
+		Where(sq.NotEq{"status": []string{domain.OrderStatusCancelled, domain.OrderStatusRejected}})
+
+	query, args, err = qb.PlaceholderFormat(sq.Dollar).ToSql()
+
+	if err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[ORDER REPOSITORY][reserveSlot] failed to convert query builder to sql")
+		return err
+	}
+
+	if err = tx.Get(&taken, query, args...); err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[ORDER REPOSITORY][reserveSlot] failed to count pickup slot orders")
+		return err
+	}
+
+	if taken >= capacity {
+		return domain.ErrSlotFull
+	}
+
+	return nil
+}
+
+// nextQueueNumber hands out the next number of the shop for the order's
+// queue date. The upsert keeps the counter row locked until the transaction
+// ends, so concurrent orders of one shop wait for each other.
+func nextQueueNumber(tx *sqlx.Tx, order *domain.Order) error {
+	var (
+		qbi   sq.InsertBuilder
+		query string
+		err   error
+		args  []any
+	)
+
+	qbi = sq.
+		Insert(QUEUE_COUNTER_TABLENAME).
+		Columns("shop_id", "queue_date", "last_number").
+		Values(order.ShopID, order.QueueDate, 1).
+		Suffix("ON CONFLICT (shop_id, queue_date) DO UPDATE SET last_number = " + QUEUE_COUNTER_TABLENAME + ".last_number + 1").
+		Suffix("RETURNING last_number")
+
+	query, args, err = qbi.PlaceholderFormat(sq.Dollar).ToSql()
+
+	if err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[ORDER REPOSITORY][nextQueueNumber] failed to convert query builder to sql")
+		return err
+	}
+
+	if err = tx.Get(&order.QueueNumber, query, args...); err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[ORDER REPOSITORY][nextQueueNumber] failed to execute sql statement")
+		return err
+	}
+
+	return nil
+}
+
+func insertOrderItem(tx *sqlx.Tx, orderID string, item *domain.OrderItem) error {
+	var (
+		qbi   sq.InsertBuilder
+		query string
+		err   error
+		args  []any
+	)
+
+	qbi = sq.
+		Insert(ORDER_ITEM_TABLENAME).
+		Columns("order_id", "menu_id", "menu_name", "quantity", "unit_price", "notes").
+		Values(orderID, item.MenuID, item.MenuName, item.Quantity, item.UnitPrice, item.Notes).
+		Suffix("RETURNING order_item_id")
+
+	query, args, err = qbi.PlaceholderFormat(sq.Dollar).ToSql()
+
+	if err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[ORDER REPOSITORY][insertOrderItem] failed to convert query builder to sql")
+		return err
+	}
+
+	if err = tx.Get(&item.ID, query, args...); err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[ORDER REPOSITORY][insertOrderItem] failed to insert order item")
+		return err
+	}
+
+	if len(item.Options) == 0 {
+		return nil
+	}
+
+	qbi = sq.
+		Insert(ORDER_ITEM_OPTION_TABLENAME).
+		Columns("order_item_id", "option_value_id", "group_name", "value_name", "price_delta")
+
+	for _, option := range item.Options {
+		qbi = qbi.Values(item.ID, option.ValueID, option.GroupName, option.ValueName, option.PriceDelta)
+	}
+
+	query, args, err = qbi.PlaceholderFormat(sq.Dollar).ToSql()
+
+	if err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[ORDER REPOSITORY][insertOrderItem] failed to convert options query builder to sql")
+		return err
+	}
+
+	if _, err = tx.Exec(query, args...); err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[ORDER REPOSITORY][insertOrderItem] failed to insert order item options")
+		return err
+	}
+
+	return nil
+}
+
+// takeStock subtracts the ordered quantities from stock tracked menus once an
+// order is accepted, and marks a menu sold out when its stock runs out. The
+// row locks of the update serialize concurrent acceptances of the same menu.
+func takeStock(tx *sqlx.Tx, orderID string) error {
+	var (
+		qb        sq.UpdateBuilder
+		query     string
+		err       error
+		args      []any
+		remaining []int64
+	)
+
+	qb = sq.
+		Update(MENU_TABLENAME).
+		Set("stock_remaining", sq.Expr("menus.stock_remaining - ordered.quantity")).
+		Set("menu_status", sq.Expr(
+			"CASE WHEN menus.stock_remaining - ordered.quantity <= 0 THEN ?::MENU_STATUS_ENUM ELSE menus.menu_status END",
+			domain.MenuStatusSoldOut,
+		)).
+		Set("version", sq.Expr("menus.version + 1")).
+		Set("updated_at", time.Now()).
+		FromSelect(sq.
+			Select("menu_id", "SUM(quantity) AS quantity").
+			From(ORDER_ITEM_TABLENAME).
+			Where("order_id = ?", orderID).
+			GroupBy("menu_id"), "ordered").
+		Where("menus.menu_id = ordered.menu_id").
+		Where("menus.stock_remaining IS NOT NULL").
+		Suffix("RETURNING menus.stock_remaining")
+
+	query, args, err = qb.PlaceholderFormat(sq.Dollar).ToSql()
+
+	if err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[ORDER REPOSITORY][takeStock] failed to convert query builder to sql")
+		return err
+	}
+
+	if err = tx.Select(&remaining, query, args...); err != nil {
+		// the check constraint rejects a negative stock
+		if strings.Contains(err.Error(), "stock_remaining_check") {
+			return domain.ErrOutOfStock
+		}
+
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[ORDER REPOSITORY][takeStock] failed to update menu stock")
+		return err
+	}
+
+	return nil
+}
+
+func insertOrderHistory(tx *sqlx.Tx, history *domain.OrderStatusHistory) error {
+	var (
+		qbi       sq.InsertBuilder
+		query     string
+		err       error
+		args      []any
+		oldStatus any
+	)
+
+	if history.OldStatus != "" {
+		oldStatus = history.OldStatus
+	}
+
+	qbi = sq.
+		Insert(ORDER_HISTORY_TABLENAME).
+		Columns("order_id", "actor_id", "actor_type", "old_status", "new_status", "reason").
+		Values(history.OrderID, history.ActorID, history.ActorType, oldStatus, history.NewStatus, history.Reason)
+
+	query, args, err = qbi.PlaceholderFormat(sq.Dollar).ToSql()
+
+	if err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[ORDER REPOSITORY][insertOrderHistory] failed to convert query builder to sql")
+		return err
+	}
+
+	if _, err = tx.Exec(query, args...); err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[ORDER REPOSITORY][insertOrderHistory] failed to insert order status history")
+		return err
+	}
+
+	return nil
+}
+
+// returnStock gives the quantities of a cancelled order back to stock tracked
+// menus, capped at the daily stock as the stock may have been reset since the
+// order was accepted. A menu sold out by its stock is available again.
+func returnStock(tx *sqlx.Tx, orderID string) error {
+	var (
+		qb    sq.UpdateBuilder
+		query string
+		err   error
+		args  []any
+	)
+
+	qb = sq.
+		Update(MENU_TABLENAME).
+		Set("stock_remaining", sq.Expr("LEAST(menus.stock_remaining + ordered.quantity, menus.daily_stock)")).
+		Set("menu_status", sq.Expr(
+			"CASE WHEN menus.menu_status = ? AND menus.stock_remaining <= 0 AND menus.daily_stock > 0 THEN ?::MENU_STATUS_ENUM ELSE menus.menu_status END",
+			domain.MenuStatusSoldOut,
+			domain.MenuStatusAvailable,
+		)).
+		Set("version", sq.Expr("menus.version + 1")).
+		Set("updated_at", time.Now()).
+		FromSelect(sq.
+			Select("menu_id", "SUM(quantity) AS quantity").
+			From(ORDER_ITEM_TABLENAME).
+			Where("order_id = ?", orderID).
+			GroupBy("menu_id"), "ordered").
+		Where("menus.menu_id = ordered.menu_id").
+		Where("menus.stock_remaining IS NOT NULL")
+
+	query, args, err = qb.PlaceholderFormat(sq.Dollar).ToSql()
+
+	if err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[ORDER REPOSITORY][returnStock] failed to convert query builder to sql")
+		return err
+	}
+
+	if _, err = tx.Exec(query, args...); err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[ORDER REPOSITORY][returnStock] failed to update menu stock")
+		return err
+	}
+
+	return nil
+}
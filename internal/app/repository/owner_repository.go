@@ -40,11 +40,13 @@ func (r *ownerRepositoryImpl) FetchAll(params *dto.OwnerParams) ([]domain.Owner,
 		err    error
 	)
 
-	qb = sq.Select("admin_id", "fullname", "wa_number", "username", "password", "created_at", "updated_at").
+	qb = sq.Select("admin_id", "fullname", "wa_number", "username", "password", "version", "created_at", "updated_at").
 		From(OWNER_TABLENAME).
 		Join("roles ON roles.role_id = admins.role_id").
 		Where("roles.role_name = ?", "Owner")
 
+	qb = paginate(qb, "admin_id", &params.Page)
+
 	query, args, err = qb.PlaceholderFormat(sq.Dollar).ToSql()
 
 	if err != nil {
@@ -74,7 +76,7 @@ func (r *ownerRepositoryImpl) FetchByID(params *dto.OwnerParams) (*domain.Owner,
 		err   error
 	)
 
-	qb = sq.Select("admin_id", "fullname", "wa_number", "username", "password", "created_at", "updated_at").
+	qb = sq.Select("admin_id", "fullname", "wa_number", "username", "password", "version", "created_at", "updated_at").
 		From(OWNER_TABLENAME).
 		Join("roles ON roles.role_id = admins.role_id").
 		Where("admin_id = ? AND roles.role_name = ?", params.ID, "Owner").
@@ -173,6 +175,7 @@ func (r *ownerRepositoryImpl) UpdateOwner(params *dto.OwnerParams, owner *domain
 		Set("fullname", owner.Fullname).
 		Set("wa_number", owner.WANumber).
 		Set("username", owner.Username).
+		Set("version", sq.Expr("version + 1")).
 		Set("updated_at", time.Now())
 
 	if owner.Password != "" {
@@ -181,6 +184,11 @@ func (r *ownerRepositoryImpl) UpdateOwner(params *dto.OwnerParams, owner *domain
 
 	qb = qb.Where("admin_id = ?", params.ID)
 
+	// only overwrites the versions the caller has seen
+	if len(params.Versions) > 0 {
+		qb = qb.Where(sq.Eq{"version": params.Versions})
+	}
+
 	query, args, err = qb.PlaceholderFormat(sq.Dollar).ToSql()
 
 	if err != nil {
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/devanfer02/filkom-canteen/internal/dto"
+)
+
+// paginate applies keyset pagination on a time sortable ulid column, newest
+// rows first. One extra row is fetched so the service can tell if more exist.
+func paginate(qb sq.SelectBuilder, column string, page *dto.PageParams) sq.SelectBuilder {
+	if page.AfterID != "" {
+		qb = qb.Where(column+" < ?", page.AfterID)
+	}
+
+	qb = qb.OrderBy(column + " DESC")
+
+	if page.Limit > 0 {
+		qb = qb.Limit(uint64(page.Limit + 1))
+	}
+
+	return qb
+}
+
+// paginateBy applies keyset pagination ordered by column with idColumn as the
+// tie breaker, column must never come from user input.
+func paginateBy(qb sq.SelectBuilder, column, idColumn string, desc bool, page *dto.PageParams) sq.SelectBuilder {
+	op, dir := ">", "ASC"
+
+	if desc {
+		op, dir = "<", "DESC"
+	}
+
+	if page.AfterID != "" {
+		qb = qb.Where(fmt.Sprintf("(%s, %s) %s (?, ?)", column, idColumn, op), page.AfterValue, page.AfterID)
+	}
+
+	qb = qb.OrderBy(column+" "+dir, idColumn+" "+dir)
+
+	if page.Limit > 0 {
+		qb = qb.Limit(uint64(page.Limit + 1))
+	}
+
+	return qb
+}
@@ -0,0 +1,20 @@
+package repository
+
+import "strings"
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// escapeLike escapes the wildcards of a LIKE pattern so user input is matched
+// literally.
+func escapeLike(value string) string {
+	return likeEscaper.Replace(value)
+}
+
+// nullable stores empty optional references as NULL.
+func nullable(value string) any {
+	if value == "" {
+		return nil
+	}
+
+	return value
+}
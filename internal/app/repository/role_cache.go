@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/devanfer02/filkom-canteen/domain"
+	"github.com/devanfer02/filkom-canteen/internal/pkg/cache"
+	"github.com/devanfer02/filkom-canteen/internal/pkg/events"
+	"github.com/devanfer02/filkom-canteen/internal/pkg/log"
+	"github.com/devanfer02/filkom-canteen/internal/pkg/redis"
+)
+
+const (
+	// the roles table is tiny, so redis keeps all of it under a single key
+	ROLE_CACHE_KEY      = "roles"
+	ROLE_CACHE_TOPIC    = "roles"
+	roleEventInvalidate = "roles.invalidated"
+	roleRedisTTL        = 10 * time.Minute
+	roleLocalTTL        = time.Minute
+	roleLocalCapacity   = 64
+)
+
+// IRoleCache is a read-through role lookup, first from memory, then redis
+// and only then the database.
+type IRoleCache interface {
+	IRoleRepository
+	// Invalidate drops every cached role on all api instances.
+	Invalidate() error
+}
+
+type roleCacheImpl struct {
+	repo   IRoleRepository
+	redis  redis.RedisInterface
+	broker events.BrokerInterface
+	local  *cache.LRU[domain.Role]
+}
+
+func NewRoleCache(repo IRoleRepository, redis redis.RedisInterface, broker events.BrokerInterface) IRoleCache {
+	c := &roleCacheImpl{
+		repo:   repo,
+		redis:  redis,
+		broker: broker,
+		local:  cache.NewLRU[domain.Role](roleLocalCapacity, roleLocalTTL),
+	}
+
+	go c.listen()
+
+	return c
+}
+
+func (c *roleCacheImpl) FetchOne(id string) (*domain.Role, error) {
+	if role, ok := c.local.Get(id); ok {
+		return &role, nil
+	}
+
+	names := c.fetchShared()
+
+	if name, ok := names[id]; ok {
+		role := domain.Role{ID: id, Name: name}
+		c.local.Set(id, role)
+
+		return &role, nil
+	}
+
+	// unknown ids are never cached, so a new role resolves right away
+	role, err := c.repo.FetchOne(id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if names == nil {
+		names = make(map[string]string, 1)
+	}
+
+	names[role.ID] = role.Name
+	c.storeShared(names)
+	c.local.Set(id, *role)
+
+	return role, nil
+}
+
+func (c *roleCacheImpl) Invalidate() error {
+	if err := c.redis.Delete(context.Background(), ROLE_CACHE_KEY); err != nil {
+		return err
+	}
+
+	c.local.Purge()
+
+	return c.broker.Publish(context.Background(), ROLE_CACHE_TOPIC, roleEventInvalidate, nil)
+}
+
+// fetchShared reads the role names kept in redis by id, a nil map means
+// nothing usable is cached.
+func (c *roleCacheImpl) fetchShared() map[string]string {
+	cached, err := c.redis.Get(context.Background(), ROLE_CACHE_KEY)
+
+	if err != nil || cached == "" {
+		return nil
+	}
+
+	var names map[string]string
+
+	if err := json.Unmarshal([]byte(cached), &names); err != nil {
+		return nil
+	}
+
+	return names
+}
+
+func (c *roleCacheImpl) storeShared(names map[string]string) {
+	payload, err := json.Marshal(names)
+
+	if err != nil {
+		return
+	}
+
+	if err := c.redis.Set(context.Background(), ROLE_CACHE_KEY, payload, roleRedisTTL); err != nil {
+		log.Warn(log.LogInfo{
+			"error": err.Error(),
+		}, "[ROLE CACHE][storeShared] failed to cache roles")
+	}
+}
+
+// listen purges the in-memory roles whenever any instance invalidates them.
+func (c *roleCacheImpl) listen() {
+	stream, _ := c.broker.Subscribe(ROLE_CACHE_TOPIC)
+
+	for range stream {
+		c.local.Purge()
+	}
+}
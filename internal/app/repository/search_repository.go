@@ -0,0 +1,157 @@
+package repository
+
+import (
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/devanfer02/filkom-canteen/domain"
+	"github.com/devanfer02/filkom-canteen/internal/dto"
+	"github.com/devanfer02/filkom-canteen/internal/pkg/log"
+)
+
+const (
+	// searchSimilarity is the minimum pg_trgm word similarity for a fuzzy hit,
+	// low enough to forgive a typo or two in a dish name.
+	searchSimilarity = 0.3
+	searchMenuLimit  = 50
+
+	menuSearchVector = "to_tsvector('simple', COALESCE(menus.menu_name, ''))"
+	shopSearchVector = "to_tsvector('simple', COALESCE(shops.shop_name, '') || ' ' || COALESCE(shops.shop_description, ''))"
+	searchQuery      = "websearch_to_tsquery('simple', ?)"
+)
+
+// full text matches always rank above fuzzy ones, similarity orders the rest
+var (
+	menuSearchScore = "(CASE WHEN " + menuSearchVector + " @@ " + searchQuery + " THEN 1 ELSE 0 END) + " +
+		"word_similarity(?, COALESCE(menus.menu_name, ''))"
+	menuSearchMatch = menuSearchVector + " @@ " + searchQuery + " OR " +
+		"word_similarity(?, COALESCE(menus.menu_name, '')) >= ?"
+
+	shopSearchScore = "(CASE WHEN " + shopSearchVector + " @@ " + searchQuery + " THEN 1 ELSE 0 END) + " +
+		"GREATEST(word_similarity(?, COALESCE(shops.shop_name, '')), word_similarity(?, COALESCE(shops.shop_description, '')) * 0.5)"
+	shopSearchMatch = shopSearchVector + " @@ " + searchQuery + " OR " +
+		"word_similarity(?, COALESCE(shops.shop_name, '')) >= ? OR " +
+		"word_similarity(?, COALESCE(shops.shop_description, '')) >= ?"
+)
+
+type ISearchRepository interface {
+	SearchShops(params *dto.SearchParams) ([]domain.SearchShopHit, error)
+	SearchMenus(params *dto.SearchParams, shopIDs []string) ([]domain.SearchMenuHit, error)
+}
+
+type searchRepositoryImpl struct {
+	conn *sqlx.DB
+}
+
+func NewSearchRepository(conn *sqlx.DB) ISearchRepository {
+	return &searchRepositoryImpl{conn}
+}
+
+// SearchShops returns shops matching by name or description as well as shops
+// owning a matching menu, scored by the best of the shop and its menus.
+func (r *searchRepositoryImpl) SearchShops(params *dto.SearchParams) ([]domain.SearchShopHit, error) {
+	var (
+		qb    sq.SelectBuilder
+		query string
+		args  []interface{}
+		shops []domain.SearchShopHit = make([]domain.SearchShopHit, 0)
+		err   error
+		q     = params.Query
+	)
+
+	qb = sq.Select(
+		"shops.shop_id",
+		"shops.shop_name",
+		"COALESCE(shops.shop_description, '') AS shop_description",
+		"COALESCE(shops.shop_photo_link, '') AS shop_photo_link",
+		"shops.manually_closed",
+		"shops.closed_reason",
+		"shops.pickup_slot_capacity",
+		"shops.version",
+		"shops.created_at",
+		"shops.updated_at",
+	).
+		Column(sq.Expr(
+			"GREATEST("+shopSearchScore+", COALESCE((SELECT MAX("+menuSearchScore+") FROM menus WHERE menus.shop_id = shops.shop_id), 0)) AS score",
+			q, q, q, q, q,
+		)).
+		From(SHOP_TABLENAME).
+		Where(sq.Or{
+			sq.Expr(shopSearchMatch, q, q, searchSimilarity, q, searchSimilarity),
+			sq.Expr(
+				"shops.shop_id IN (SELECT menus.shop_id FROM menus WHERE "+menuSearchMatch+")",
+				q, q, searchSimilarity,
+			),
+		}).
+		OrderBy("score DESC", "shops.shop_id DESC").
+		Limit(uint64(params.Limit))
+
+	query, args, err = qb.PlaceholderFormat(sq.Dollar).ToSql()
+
+	if err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[SEARCH REPOSITORY][SearchShops] failed to convert query builder to sql")
+		return nil, err
+	}
+
+	if err = r.conn.Select(&shops, query, args...); err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[SEARCH REPOSITORY][SearchShops] failed to search shops")
+		return nil, err
+	}
+
+	return shops, nil
+}
+
+// SearchMenus returns the matching menus of the given shops.
+func (r *searchRepositoryImpl) SearchMenus(params *dto.SearchParams, shopIDs []string) ([]domain.SearchMenuHit, error) {
+	var (
+		qb    sq.SelectBuilder
+		query string
+		args  []interface{}
+		menus []domain.SearchMenuHit = make([]domain.SearchMenuHit, 0)
+		err   error
+		q     = params.Query
+	)
+
+	qb = sq.Select(
+		"menus.menu_id",
+		"menus.menu_name",
+		"menus.shop_id AS menu_shop_id",
+		"COALESCE(menus.category_id::text, '') AS menu_category_id",
+		"menus.menu_price",
+		"menus.menu_status",
+		"menus.daily_stock",
+		"menus.stock_remaining",
+		"COALESCE(menus.menu_photo_link, '') AS menu_photo_link",
+		"menus.version",
+		"menus.created_at",
+		"menus.updated_at",
+	).
+		Column(sq.Expr(menuSearchScore+" AS score", q, q)).
+		From(MENU_TABLENAME).
+		Where(sq.Eq{"menus.shop_id": shopIDs}).
+		Where(sq.Expr("("+menuSearchMatch+")", q, q, searchSimilarity)).
+		OrderBy("score DESC", "menus.menu_id DESC").
+		Limit(searchMenuLimit)
+
+	query, args, err = qb.PlaceholderFormat(sq.Dollar).ToSql()
+
+	if err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[SEARCH REPOSITORY][SearchMenus] failed to convert query builder to sql")
+		return nil, err
+	}
+
+	if err = r.conn.Select(&menus, query, args...); err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[SEARCH REPOSITORY][SearchMenus] failed to search menus")
+		return nil, err
+	}
+
+	return menus, nil
+}
@@ -15,11 +15,13 @@ import (
 const SHOP_TABLENAME = "shops"
 
 type IShopRepository interface {
-	FetchAllShops() ([]domain.Shop, error)
+	FetchAllShops(params *dto.ShopParams) ([]domain.Shop, error)
 	FetchShopByID(params *dto.ShopParams) (*domain.Shop, error)
 	InsertShop(shop *domain.Shop) error
 	InsertShopOwner(params *dto.ShopParams) error
+	IsShopOwner(params *dto.ShopParams) (bool, error)
 	UpdateShop(params *dto.ShopParams, shop *domain.Shop) error
+	UpdateShopStatus(params *dto.ShopParams, shop *domain.Shop) error
 	DeleteShopOwner(params *dto.ShopParams) error
 	DeleteShop(params *dto.ShopParams) error
 }
@@ -32,17 +34,19 @@ func NewShowRepository(conn *sqlx.DB) IShopRepository {
 	return &shopRepositoryImpl{conn: conn}
 }
 
-func (r *shopRepositoryImpl) FetchAllShops() ([]domain.Shop, error) {
+func (r *shopRepositoryImpl) FetchAllShops(params *dto.ShopParams) ([]domain.Shop, error) {
 	var (
 		qb    sq.SelectBuilder
 		query string
 		err   error
+		args  []interface{}
 		shops []domain.Shop = make([]domain.Shop, 0)
 	)
 
 	qb = sq.Select("*").From(SHOP_TABLENAME)
+	qb = paginate(qb, "shop_id", &params.Page)
 
-	query, _, err = qb.ToSql()
+	query, args, err = qb.PlaceholderFormat(sq.Dollar).ToSql()
 
 	if err != nil {
 		log.Error(log.LogInfo{
@@ -51,7 +55,7 @@ func (r *shopRepositoryImpl) FetchAllShops() ([]domain.Shop, error) {
 		return nil, err
 	}
 
-	if err = r.conn.Select(&shops, query); err != nil {
+	if err = r.conn.Select(&shops, query, args...); err != nil {
 		log.Error(log.LogInfo{
 			"error": err.Error(),
 		}, "[SHOP REPOSITORY][FetchAllShops] failed to fetch shops")
@@ -105,8 +109,8 @@ func (r *shopRepositoryImpl) InsertShop(shop *domain.Shop) error {
 
 	qb = sq.
 		Insert(SHOP_TABLENAME).
-		Columns("shop_name", "shop_description", "shop_photo_link").
-		Values(shop.Name, shop.Description, shop.PhotoLink)
+		Columns("shop_name", "shop_description", "shop_photo_link", "pickup_slot_capacity").
+		Values(shop.Name, shop.Description, shop.PhotoLink, shop.SlotCapacity)
 
 	query, args, err = qb.PlaceholderFormat(sq.Dollar).ToSql()
 
@@ -160,6 +164,41 @@ func (r *shopRepositoryImpl) InsertShopOwner(params *dto.ShopParams) error {
 	return nil
 }
 
+func (r *shopRepositoryImpl) IsShopOwner(params *dto.ShopParams) (bool, error) {
+	var (
+		qb     sq.SelectBuilder
+		query  string
+		err    error
+		args   []any
+		exists bool
+	)
+
+	qb = sq.
+		Select("1").
+		From("shop_owners").
+		Where("shop_id = ? AND admin_id = ?", params.ID, params.OwnerID).
+		Prefix("SELECT EXISTS (").
+		Suffix(")")
+
+	query, args, err = qb.PlaceholderFormat(sq.Dollar).ToSql()
+
+	if err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[SHOP REPOSITORY][IsShopOwner] failed to convert query builder to sql")
+		return false, err
+	}
+
+	if err = r.conn.Get(&exists, query, args...); err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[SHOP REPOSITORY][IsShopOwner] failed to check shop owner")
+		return false, err
+	}
+
+	return exists, nil
+}
+
 func (r *shopRepositoryImpl) DeleteShopOwner(params *dto.ShopParams) error {
 	var (
 		qb    sq.DeleteBuilder
@@ -210,9 +249,16 @@ func (r *shopRepositoryImpl) UpdateShop(params *dto.ShopParams, shop *domain.Sho
 		Set("shop_name", shop.Name).
 		Set("shop_description", shop.Description).
 		Set("shop_photo_link", shop.PhotoLink).
+		Set("pickup_slot_capacity", shop.SlotCapacity).
+		Set("version", sq.Expr("version + 1")).
 		Set("updated_at", time.Now()).
 		Where("shop_id = ?", params.ID)
 
+	// only overwrites the versions the caller has seen
+	if len(params.Versions) > 0 {
+		qb = qb.Where(sq.Eq{"version": params.Versions})
+	}
+
 	query, args, err = qb.PlaceholderFormat(sq.Dollar).ToSql()
 
 	if err != nil {
@@ -239,6 +285,51 @@ func (r *shopRepositoryImpl) UpdateShop(params *dto.ShopParams, shop *domain.Sho
 	return nil
 }
 
+func (r *shopRepositoryImpl) UpdateShopStatus(params *dto.ShopParams, shop *domain.Shop) error {
+	var (
+		qb    sq.UpdateBuilder
+		query string
+		err   error
+		args  []any
+	)
+
+	qb = sq.
+		Update(SHOP_TABLENAME).
+		Set("manually_closed", shop.ManuallyClosed).
+		Set("closed_reason", shop.ClosedReason).
+		Set("version", sq.Expr("version + 1")).
+		Set("updated_at", time.Now()).
+		Where("shop_id = ?", params.ID)
+
+	if len(params.Versions) > 0 {
+		qb = qb.Where(sq.Eq{"version": params.Versions})
+	}
+
+	query, args, err = qb.PlaceholderFormat(sq.Dollar).ToSql()
+
+	if err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[SHOP REPOSITORY][UpdateShopStatus] failed to convert query builder to sql")
+		return err
+	}
+
+	res, err := r.conn.Exec(query, args...)
+
+	if err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[SHOP REPOSITORY][UpdateShopStatus] failed to execute sql statement")
+		return err
+	}
+
+	if rows, _ := res.RowsAffected(); rows < 1 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
 func (r *shopRepositoryImpl) DeleteShop(params *dto.ShopParams) error {
 	var (
 		qb    sq.DeleteBuilder
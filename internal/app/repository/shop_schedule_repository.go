@@ -0,0 +1,282 @@
+package repository
+
+import (
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/devanfer02/filkom-canteen/domain"
+	"github.com/devanfer02/filkom-canteen/internal/dto"
+	"github.com/devanfer02/filkom-canteen/internal/pkg/log"
+)
+
+const (
+	SHOP_HOURS_TABLENAME   = "shop_hours"
+	SHOP_CLOSURE_TABLENAME = "shop_closures"
+)
+
+type IShopScheduleRepository interface {
+	FetchHours(shopIDs []string) ([]domain.ShopHours, error)
+	ReplaceHours(params *dto.ShopScheduleParams, hours []domain.ShopHours) error
+	FetchClosures(shopIDs []string, from, to string) ([]domain.ShopClosure, error)
+	InsertClosure(closure *domain.ShopClosure) error
+	DeleteClosure(params *dto.ShopScheduleParams) error
+}
+
+type shopScheduleRepositoryImpl struct {
+	conn *sqlx.DB
+}
+
+func NewShopScheduleRepository(conn *sqlx.DB) IShopScheduleRepository {
+	return &shopScheduleRepositoryImpl{conn}
+}
+
+func (r *shopScheduleRepositoryImpl) FetchHours(shopIDs []string) ([]domain.ShopHours, error) {
+	var (
+		qb    sq.SelectBuilder
+		query string
+		args  []interface{}
+		hours []domain.ShopHours = make([]domain.ShopHours, 0)
+		err   error
+	)
+
+	if len(shopIDs) == 0 {
+		return hours, nil
+	}
+
+	qb = sq.Select(
+		"shop_hour_id",
+		"shop_id",
+		"weekday",
+		"to_char(open_time, 'HH24:MI') AS open_time",
+		"to_char(close_time, 'HH24:MI') AS close_time",
+	).From(SHOP_HOURS_TABLENAME).
+		Where(sq.Eq{"shop_id": shopIDs}).
+		OrderBy("weekday ASC", "open_time ASC")
+
+	query, args, err = qb.PlaceholderFormat(sq.Dollar).ToSql()
+
+	if err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[SHOP SCHEDULE REPOSITORY][FetchHours] failed to convert query builder to sql")
+		return nil, err
+	}
+
+	if err = r.conn.Select(&hours, query, args...); err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[SHOP SCHEDULE REPOSITORY][FetchHours] failed to fetch shop hours")
+		return nil, err
+	}
+
+	return hours, nil
+}
+
+func (r *shopScheduleRepositoryImpl) ReplaceHours(params *dto.ShopScheduleParams, hours []domain.ShopHours) error {
+	var (
+		qbd   sq.DeleteBuilder
+		qbi   sq.InsertBuilder
+		query string
+		err   error
+		args  []any
+		tx    *sqlx.Tx
+	)
+
+	tx, err = r.conn.Beginx()
+
+	if err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[SHOP SCHEDULE REPOSITORY][ReplaceHours] failed to begin transaction")
+		return err
+	}
+
+	defer tx.Rollback()
+
+	qbd = sq.
+		Delete(SHOP_HOURS_TABLENAME).
+		Where("shop_id = ?", params.ShopID)
+
+	query, args, err = qbd.PlaceholderFormat(sq.Dollar).ToSql()
+
+	if err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[SHOP SCHEDULE REPOSITORY][ReplaceHours] failed to convert delete query builder to sql")
+		return err
+	}
+
+	if _, err = tx.Exec(query, args...); err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[SHOP SCHEDULE REPOSITORY][ReplaceHours] failed to delete shop hours")
+		return err
+	}
+
+	if len(hours) > 0 {
+		qbi = sq.
+			Insert(SHOP_HOURS_TABLENAME).
+			Columns("shop_id", "weekday", "open_time", "close_time")
+
+		for _, interval := range hours {
+			qbi = qbi.Values(params.ShopID, interval.Weekday, interval.OpenTime, interval.CloseTime)
+		}
+
+		query, args, err = qbi.PlaceholderFormat(sq.Dollar).ToSql()
+
+		if err != nil {
+			log.Error(log.LogInfo{
+				"error": err.Error(),
+			}, "[SHOP SCHEDULE REPOSITORY][ReplaceHours] failed to convert insert query builder to sql")
+			return err
+		}
+
+		if _, err = tx.Exec(query, args...); err != nil {
+			if strings.Contains(err.Error(), "violates") {
+				return domain.ErrBadRequest
+			}
+
+			log.Error(log.LogInfo{
+				"error": err.Error(),
+			}, "[SHOP SCHEDULE REPOSITORY][ReplaceHours] failed to insert shop hours")
+			return err
+		}
+	}
+
+	if err = bumpVersion(tx, SHOP_TABLENAME, "shop_id", params.ShopID, params.Versions); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[SHOP SCHEDULE REPOSITORY][ReplaceHours] failed to commit transaction")
+		return err
+	}
+
+	return nil
+}
+
+// FetchClosures lists the closures of the given shops between two dates,
+// both inclusive, an empty to leaves the range open ended.
+func (r *shopScheduleRepositoryImpl) FetchClosures(shopIDs []string, from, to string) ([]domain.ShopClosure, error) {
+	var (
+		qb       sq.SelectBuilder
+		query    string
+		args     []interface{}
+		closures []domain.ShopClosure = make([]domain.ShopClosure, 0)
+		err      error
+	)
+
+	if len(shopIDs) == 0 {
+		return closures, nil
+	}
+
+	qb = sq.Select(
+		"closure_id",
+		"shop_id",
+		"to_char(closed_on, 'YYYY-MM-DD') AS closed_on",
+		"reason",
+	).From(SHOP_CLOSURE_TABLENAME).
+		Where(sq.Eq{"shop_id": shopIDs}).
+		Where("closed_on >= ?", from).
+		OrderBy("closed_on ASC")
+
+	if to != "" {
+		qb = qb.Where("closed_on <= ?", to)
+	}
+
+	query, args, err = qb.PlaceholderFormat(sq.Dollar).ToSql()
+
+	if err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[SHOP SCHEDULE REPOSITORY][FetchClosures] failed to convert query builder to sql")
+		return nil, err
+	}
+
+	if err = r.conn.Select(&closures, query, args...); err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[SHOP SCHEDULE REPOSITORY][FetchClosures] failed to fetch shop closures")
+		return nil, err
+	}
+
+	return closures, nil
+}
+
+func (r *shopScheduleRepositoryImpl) InsertClosure(closure *domain.ShopClosure) error {
+	var (
+		qbi   sq.InsertBuilder
+		query string
+		err   error
+		args  []any
+	)
+
+	qbi = sq.
+		Insert(SHOP_CLOSURE_TABLENAME).
+		Columns("shop_id", "closed_on", "reason").
+		Values(closure.ShopID, closure.Date, closure.Reason).
+		Suffix("RETURNING closure_id")
+
+	query, args, err = qbi.PlaceholderFormat(sq.Dollar).ToSql()
+
+	if err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[SHOP SCHEDULE REPOSITORY][InsertClosure] failed to convert query builder to sql")
+		return err
+	}
+
+	if err = r.conn.Get(&closure.ID, query, args...); err != nil {
+		if strings.Contains(err.Error(), "unique constraint") {
+			return domain.ErrDuplicateEntry
+		}
+
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[SHOP SCHEDULE REPOSITORY][InsertClosure] failed to execute sql statement")
+		return err
+	}
+
+	return bumpVersion(r.conn, SHOP_TABLENAME, "shop_id", closure.ShopID, nil)
+}
+
+func (r *shopScheduleRepositoryImpl) DeleteClosure(params *dto.ShopScheduleParams) error {
+	var (
+		qb    sq.DeleteBuilder
+		query string
+		args  []interface{}
+		err   error
+	)
+
+	qb = sq.
+		Delete(SHOP_CLOSURE_TABLENAME).
+		Where("closure_id = ? AND shop_id = ?", params.ClosureID, params.ShopID)
+
+	query, args, err = qb.PlaceholderFormat(sq.Dollar).ToSql()
+
+	if err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[SHOP SCHEDULE REPOSITORY][DeleteClosure] failed to convert query builder to sql")
+		return err
+	}
+
+	res, err := r.conn.Exec(query, args...)
+
+	if err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[SHOP SCHEDULE REPOSITORY][DeleteClosure] failed to execute sql statement")
+		return err
+	}
+
+	if rows, _ := res.RowsAffected(); rows < 1 {
+		return domain.ErrNotFound
+	}
+
+	return bumpVersion(r.conn, SHOP_TABLENAME, "shop_id", params.ShopID, nil)
+}
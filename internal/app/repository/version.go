@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/devanfer02/filkom-canteen/domain"
+	"github.com/devanfer02/filkom-canteen/internal/pkg/log"
+)
+
+// bumpVersion moves the version of a row on after a change to what is
+// returned along with it, such as the options of a menu or the hours of a
+// shop, so a cached copy or an update made against it no longer matches.
+// Given versions guard the change like the If-Match of a direct update.
+func bumpVersion(exec sqlx.Execer, table, idColumn, id string, versions []int64) error {
+	qb := sq.
+		Update(table).
+		Set("version", sq.Expr("version + 1")).
+		Set("updated_at", time.Now()).
+		Where(idColumn+" = ?", id)
+
+	if len(versions) > 0 {
+		qb = qb.Where(sq.Eq{"version": versions})
+	}
+
+	query, args, err := qb.PlaceholderFormat(sq.Dollar).ToSql()
+
+	if err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[REPOSITORY][bumpVersion] failed to convert query builder to sql")
+		return err
+	}
+
+	res, err := exec.Exec(query, args...)
+
+	if err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+			"table": table,
+		}, "[REPOSITORY][bumpVersion] failed to bump version")
+		return err
+	}
+
+	if rows, _ := res.RowsAffected(); rows < 1 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"github.com/devanfer02/filkom-canteen/domain"
+	"github.com/devanfer02/filkom-canteen/internal/app/repository"
+	"github.com/devanfer02/filkom-canteen/internal/dto"
+)
+
+// authorizeShop checks whether an admin token may manage the given shop.
+// Admins manage every shop, owners only the shops listed for them in shop_owners.
+func authorizeShop(shopRepo repository.IShopRepository, shopID, actorID, actorRole string) error {
+	switch actorRole {
+	case domain.RoleAdmin:
+		return nil
+	case domain.RoleOwner:
+		owned, err := shopRepo.IsShopOwner(&dto.ShopParams{
+			ID:      shopID,
+			OwnerID: actorID,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		if !owned {
+			return domain.ErrForbidden
+		}
+
+		return nil
+	default:
+		return domain.ErrForbidden
+	}
+}
+
+// authorizeOwner lets admins manage every owner account, while owners may
+// only manage their own.
+func authorizeOwner(ownerID, actorID, actorRole string) error {
+	switch actorRole {
+	case domain.RoleAdmin:
+		return nil
+	case domain.RoleOwner:
+		if ownerID != actorID {
+			return domain.ErrForbidden
+		}
+
+		return nil
+	default:
+		return domain.ErrForbidden
+	}
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"sync"
+
+	"github.com/devanfer02/filkom-canteen/domain"
+	"github.com/devanfer02/filkom-canteen/internal/dto"
+	"github.com/devanfer02/filkom-canteen/internal/pkg/clock"
+	enc "github.com/devanfer02/filkom-canteen/internal/pkg/encoder"
+	"github.com/devanfer02/filkom-canteen/internal/pkg/events"
+	"github.com/devanfer02/filkom-canteen/internal/pkg/log"
+)
+
+// FetchBoard returns today's queue of every shop, or of params.ShopID only.
+func (s *orderServiceImpl) FetchBoard(params *dto.OrderParams) ([]domain.BoardShop, error) {
+	if params.ShopID != "" {
+		shopID, err := decodeID(params.ShopID)
+
+		if err != nil {
+			return nil, err
+		}
+
+		params.ShopID = shopID
+	}
+
+	params.QueueDate = clock.Today()
+
+	board, err := s.orderRepo.FetchBoard(params)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for idx := range board {
+		board[idx].ShopID = enc.Encode(board[idx].ShopID)
+	}
+
+	return board, nil
+}
+
+// SubscribeBoard starts with the current board of every requested shop and
+// then follows with the full board of a shop each time it changes, so a
+// display only ever has to replace what it shows.
+func (s *orderServiceImpl) SubscribeBoard(params *dto.OrderParams) (<-chan events.Event, func(), error) {
+	topic := events.BoardTopic
+
+	if params.ShopID != "" {
+		shopID, err := decodeID(params.ShopID)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		topic = events.ShopBoardTopic(shopID)
+	}
+
+	// subscribe first so no change between the snapshot and the stream is lost
+	stream, unsubscribe := s.broker.Subscribe(topic)
+
+	board, err := s.FetchBoard(params)
+
+	if err != nil {
+		unsubscribe()
+		return nil, nil, err
+	}
+
+	var (
+		out      = make(chan events.Event)
+		done     = make(chan struct{})
+		stopOnce sync.Once
+	)
+
+	go func() {
+		defer close(out)
+
+		for _, shop := range board {
+			event, err := events.NewEvent(domain.BoardEventUpdated, shop)
+
+			if err != nil {
+				continue
+			}
+
+			select {
+			case out <- event:
+			case <-done:
+				return
+			}
+		}
+
+		for {
+			select {
+			case event, ok := <-stream:
+				if !ok {
+					return
+				}
+
+				select {
+				case out <- event:
+				case <-done:
+					return
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return out, func() {
+		stopOnce.Do(func() {
+			close(done)
+			unsubscribe()
+		})
+	}, nil
+}
+
+// publishBoard sends the current board of a shop to the displays following
+// that shop and to those following every shop.
+func (s *orderServiceImpl) publishBoard(shopID string) {
+	board, err := s.orderRepo.FetchBoard(&dto.OrderParams{
+		ShopID:    shopID,
+		QueueDate: clock.Today(),
+	})
+
+	if err != nil || len(board) == 0 {
+		return
+	}
+
+	shop := board[0]
+	shop.ShopID = enc.Encode(shop.ShopID)
+
+	for _, topic := range []string{events.ShopBoardTopic(shopID), events.BoardTopic} {
+		if err := s.broker.Publish(context.Background(), topic, domain.BoardEventUpdated, shop); err != nil {
+			log.Warn(log.LogInfo{
+				"error": err.Error(),
+				"topic": topic,
+			}, "[ORDER SERVICE][publishBoard] failed to publish board update")
+		}
+	}
+}
@@ -0,0 +1,18 @@
+package service
+
+import (
+	"context"
+
+	"github.com/devanfer02/filkom-canteen/internal/pkg/cache"
+	"github.com/devanfer02/filkom-canteen/internal/pkg/log"
+)
+
+// invalidateCatalogue drops the cached public shop and menu responses after
+// a write, if it fails they are only served until they expire.
+func invalidateCatalogue(catalogue cache.ResponseCacheInterface) {
+	if err := catalogue.Invalidate(context.Background()); err != nil {
+		log.Warn(log.LogInfo{
+			"error": err.Error(),
+		}, "[SERVICE][invalidateCatalogue] failed to invalidate cached responses")
+	}
+}
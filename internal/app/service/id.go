@@ -0,0 +1,22 @@
+package service
+
+import (
+	"github.com/devanfer02/filkom-canteen/domain"
+	enc "github.com/devanfer02/filkom-canteen/internal/pkg/encoder"
+	"github.com/google/uuid"
+)
+
+// decodeID turns an encoded id from a request into the raw uuid.
+func decodeID(encoded string) (string, error) {
+	decoded, err := enc.Decode(encoded)
+
+	if err != nil {
+		return "", domain.ErrBadRequest
+	}
+
+	if _, err := uuid.Parse(decoded); err != nil {
+		return "", domain.ErrBadRequest
+	}
+
+	return decoded, nil
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"github.com/devanfer02/filkom-canteen/domain"
+	"github.com/devanfer02/filkom-canteen/internal/app/repository"
+	"github.com/devanfer02/filkom-canteen/internal/dto"
+	"github.com/devanfer02/filkom-canteen/internal/pkg/cache"
+	enc "github.com/devanfer02/filkom-canteen/internal/pkg/encoder"
+)
+
+type IMenuCategoryService interface {
+	FetchCategories(params *dto.MenuCategoryParams) ([]domain.MenuCategory, error)
+	CreateCategory(params *dto.MenuCategoryParams, req *dto.MenuCategoryRequest) error
+	UpdateCategory(params *dto.MenuCategoryParams, req *dto.MenuCategoryRequest) error
+	DeleteCategory(params *dto.MenuCategoryParams) error
+}
+
+type menuCategoryServiceImpl struct {
+	categoryRepo repository.IMenuCategoryRepository
+	shopRepo     repository.IShopRepository
+	catalogue    cache.ResponseCacheInterface
+}
+
+func NewMenuCategoryService(
+	categoryRepo repository.IMenuCategoryRepository,
+	shopRepo repository.IShopRepository,
+	catalogue cache.ResponseCacheInterface,
+) IMenuCategoryService {
+	return &menuCategoryServiceImpl{categoryRepo, shopRepo, catalogue}
+}
+
+func (s *menuCategoryServiceImpl) FetchCategories(params *dto.MenuCategoryParams) ([]domain.MenuCategory, error) {
+	shopID, err := decodeID(params.ShopID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	params.ShopID = shopID
+
+	categories, err := s.categoryRepo.FetchByShop(params)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for idx := range categories {
+		encodeCategory(&categories[idx])
+	}
+
+	return categories, nil
+}
+
+func (s *menuCategoryServiceImpl) CreateCategory(params *dto.MenuCategoryParams, req *dto.MenuCategoryRequest) error {
+	shopID, err := decodeID(params.ShopID)
+
+	if err != nil {
+		return err
+	}
+
+	if err := authorizeShop(s.shopRepo, shopID, params.ActorID, params.ActorRole); err != nil {
+		return err
+	}
+
+	err = s.categoryRepo.InsertCategory(&domain.MenuCategory{
+		ShopID:       shopID,
+		Name:         req.Name,
+		DisplayOrder: req.DisplayOrder,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	invalidateCatalogue(s.catalogue)
+
+	return nil
+}
+
+func (s *menuCategoryServiceImpl) UpdateCategory(params *dto.MenuCategoryParams, req *dto.MenuCategoryRequest) error {
+	if err := s.decodeParams(params); err != nil {
+		return err
+	}
+
+	if err := authorizeShop(s.shopRepo, params.ShopID, params.ActorID, params.ActorRole); err != nil {
+		return err
+	}
+
+	err := s.categoryRepo.UpdateCategory(params, &domain.MenuCategory{
+		Name:         req.Name,
+		DisplayOrder: req.DisplayOrder,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	invalidateCatalogue(s.catalogue)
+
+	return nil
+}
+
+func (s *menuCategoryServiceImpl) DeleteCategory(params *dto.MenuCategoryParams) error {
+	if err := s.decodeParams(params); err != nil {
+		return err
+	}
+
+	if err := authorizeShop(s.shopRepo, params.ShopID, params.ActorID, params.ActorRole); err != nil {
+		return err
+	}
+
+	if err := s.categoryRepo.DeleteCategory(params); err != nil {
+		return err
+	}
+
+	invalidateCatalogue(s.catalogue)
+
+	return nil
+}
+
+func (s *menuCategoryServiceImpl) decodeParams(params *dto.MenuCategoryParams) error {
+	shopID, err := decodeID(params.ShopID)
+
+	if err != nil {
+		return err
+	}
+
+	categoryID, err := decodeID(params.ID)
+
+	if err != nil {
+		return err
+	}
+
+	params.ShopID = shopID
+	params.ID = categoryID
+
+	return nil
+}
+
+func encodeCategory(category *domain.MenuCategory) {
+	category.ID = enc.Encode(category.ID)
+	category.ShopID = enc.Encode(category.ShopID)
+}
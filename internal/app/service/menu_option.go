@@ -0,0 +1,137 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/devanfer02/filkom-canteen/domain"
+	"github.com/devanfer02/filkom-canteen/internal/dto"
+	enc "github.com/devanfer02/filkom-canteen/internal/pkg/encoder"
+)
+
+// buildOptionGroups validates an options payload and turns it into the
+// groups stored for a menu, keeping the request order as display order.
+func buildOptionGroups(req *dto.MenuOptionsRequest) ([]domain.MenuOptionGroup, error) {
+	groups := make([]domain.MenuOptionGroup, 0, len(req.Groups))
+	names := make(map[string]bool, len(req.Groups))
+
+	for idx, reqGroup := range req.Groups {
+		name := strings.TrimSpace(reqGroup.Name)
+		key := strings.ToLower(name)
+
+		if name == "" || names[key] {
+			return nil, domain.ErrBadRequest
+		}
+
+		names[key] = true
+
+		group := domain.MenuOptionGroup{
+			Name:         name,
+			MinSelect:    reqGroup.MinSelect,
+			MaxSelect:    reqGroup.MaxSelect,
+			Required:     reqGroup.Required,
+			DisplayOrder: idx,
+			Values:       make([]domain.MenuOptionValue, 0, len(reqGroup.Values)),
+		}
+
+		if group.MinSelection() > group.MaxSelect || group.MinSelection() > len(reqGroup.Values) {
+			return nil, domain.ErrBadRequest
+		}
+
+		valueNames := make(map[string]bool, len(reqGroup.Values))
+
+		for valueIdx, reqValue := range reqGroup.Values {
+			valueName := strings.TrimSpace(reqValue.Name)
+			valueKey := strings.ToLower(valueName)
+
+			if valueName == "" || valueNames[valueKey] {
+				return nil, domain.ErrBadRequest
+			}
+
+			valueNames[valueKey] = true
+
+			group.Values = append(group.Values, domain.MenuOptionValue{
+				Name:         valueName,
+				PriceDelta:   reqValue.PriceDelta,
+				Available:    reqValue.Available == nil || *reqValue.Available,
+				DisplayOrder: valueIdx,
+			})
+		}
+
+		groups = append(groups, group)
+	}
+
+	return groups, nil
+}
+
+// selectOptions checks the option values chosen for an order item against the
+// menu's groups and returns their snapshots with the summed price delta.
+func selectOptions(groups []domain.MenuOptionGroup, selected []string) ([]domain.OrderItemOption, int64, error) {
+	var (
+		options = make([]domain.OrderItemOption, 0, len(selected))
+		counts  = make(map[string]int, len(groups))
+		chosen  = make(map[string]bool, len(selected))
+		delta   int64
+	)
+
+	for _, encoded := range selected {
+		valueID, err := decodeID(encoded)
+
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if chosen[valueID] {
+			return nil, 0, domain.ErrBadRequest
+		}
+
+		chosen[valueID] = true
+		group, value := findOptionValue(groups, valueID)
+
+		if value == nil || !value.Available {
+			return nil, 0, domain.ErrBadRequest
+		}
+
+		counts[group.ID]++
+		delta += value.PriceDelta
+
+		options = append(options, domain.OrderItemOption{
+			ValueID:    value.ID,
+			GroupName:  group.Name,
+			ValueName:  value.Name,
+			PriceDelta: value.PriceDelta,
+		})
+	}
+
+	for idx := range groups {
+		count := counts[groups[idx].ID]
+
+		if count < groups[idx].MinSelection() || count > groups[idx].MaxSelect {
+			return nil, 0, domain.ErrBadRequest
+		}
+	}
+
+	return options, delta, nil
+}
+
+func findOptionValue(groups []domain.MenuOptionGroup, valueID string) (*domain.MenuOptionGroup, *domain.MenuOptionValue) {
+	for groupIdx := range groups {
+		for valueIdx := range groups[groupIdx].Values {
+			if groups[groupIdx].Values[valueIdx].ID == valueID {
+				return &groups[groupIdx], &groups[groupIdx].Values[valueIdx]
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+func encodeOptionGroups(groups []domain.MenuOptionGroup) {
+	for groupIdx := range groups {
+		groups[groupIdx].ID = enc.Encode(groups[groupIdx].ID)
+
+		for valueIdx := range groups[groupIdx].Values {
+			value := &groups[groupIdx].Values[valueIdx]
+			value.ID = enc.Encode(value.ID)
+		}
+	}
+}
@@ -1,42 +1,156 @@
 package service
 
 import (
+	"strconv"
+
 	"github.com/devanfer02/filkom-canteen/domain"
 	"github.com/devanfer02/filkom-canteen/internal/app/repository"
 	"github.com/devanfer02/filkom-canteen/internal/dto"
+	"github.com/devanfer02/filkom-canteen/internal/pkg/cache"
+	"github.com/devanfer02/filkom-canteen/internal/pkg/clock"
 	enc "github.com/devanfer02/filkom-canteen/internal/pkg/encoder"
+	"github.com/devanfer02/filkom-canteen/internal/pkg/log"
+	"github.com/devanfer02/filkom-canteen/internal/pkg/storage"
 	"github.com/google/uuid"
 )
 
 type IMenuService interface {
-	FetchAllMenus(params *dto.MenuParams) ([]domain.Menu, error)
+	FetchAllMenus(params *dto.MenuParams) ([]domain.Menu, *dto.Pagination, error)
+	FetchMenuSections(params *dto.MenuParams) ([]domain.MenuSection, error)
 	FetchMenuByID(params *dto.MenuParams) (*domain.Menu, error)
 	CreateMenu(params *dto.MenuParams, req *dto.MenuRequest) error
 	UpdateMenu(params *dto.MenuParams, req *dto.MenuRequest) error
+	UpdateMenuOptions(params *dto.MenuParams, req *dto.MenuOptionsRequest) error
+	ResetDailyStock() error
 	DeleteMenu(params *dto.MenuParams) error
 }
 
 type menuServiceImpl struct {
-	menuRepo repository.IMenuRepository
+	menuRepo     repository.IMenuRepository
+	shopRepo     repository.IShopRepository
+	categoryRepo repository.IMenuCategoryRepository
+	optionRepo   repository.IMenuOptionRepository
+	storage      storage.StorageInterface
+	catalogue    cache.ResponseCacheInterface
 }
 
-func NewMenuService(menuRepo repository.IMenuRepository) IMenuService {
-	return &menuServiceImpl{menuRepo}
+func NewMenuService(
+	menuRepo repository.IMenuRepository,
+	shopRepo repository.IShopRepository,
+	categoryRepo repository.IMenuCategoryRepository,
+	optionRepo repository.IMenuOptionRepository,
+	storage storage.StorageInterface,
+	catalogue cache.ResponseCacheInterface,
+) IMenuService {
+	return &menuServiceImpl{menuRepo, shopRepo, categoryRepo, optionRepo, storage, catalogue}
 }
 
-func (s *menuServiceImpl) FetchAllMenus(params *dto.MenuParams) ([]domain.Menu, error) {
+func (s *menuServiceImpl) FetchAllMenus(params *dto.MenuParams) ([]domain.Menu, *dto.Pagination, error) {
+	if err := decodeMenuFilter(params); err != nil {
+		return nil, nil, err
+	}
+
+	if err := decodeMenuPage(params); err != nil {
+		return nil, nil, err
+	}
+
+	menus, err := s.menuRepo.FetchAll(params)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	menus, pagination := paginate(menus, &params.Page, func(menu *domain.Menu) []string {
+		switch params.Filter.Sort {
+		case dto.MenuSortPriceAsc, dto.MenuSortPriceDesc:
+			return []string{strconv.FormatInt(menu.Price, 10), menu.ID}
+		case dto.MenuSortNameAsc, dto.MenuSortNameDesc:
+			return []string{menu.Name, menu.ID}
+		case dto.MenuSortPopular:
+			return []string{strconv.FormatInt(menu.Sold, 10), menu.ID}
+		default:
+			return []string{menu.ID}
+		}
+	})
+
+	for idx := range menus {
+		s.encodeMenu(&menus[idx])
+	}
+
+	return menus, pagination, nil
+}
+
+// FetchMenuSections lists every menu of a shop grouped by category in display
+// order, uncategorized menus come last. Categories without a matching menu
+// are left out so filters never return empty sections.
+func (s *menuServiceImpl) FetchMenuSections(params *dto.MenuParams) ([]domain.MenuSection, error) {
+	if params.ShopID == "" {
+		return nil, domain.ErrBadRequest
+	}
+
+	if err := decodeMenuFilter(params); err != nil {
+		return nil, err
+	}
+
+	if params.Filter.Sort == "" {
+		params.Filter.Sort = dto.MenuSortNameAsc
+	}
+
+	// a shop menu is small enough to be returned whole
+	params.Page = dto.PageParams{}
+
+	categories, err := s.categoryRepo.FetchByShop(&dto.MenuCategoryParams{ShopID: params.ShopID})
+
+	if err != nil {
+		return nil, err
+	}
+
 	menus, err := s.menuRepo.FetchAll(params)
 
 	if err != nil {
-		return nil, err 
+		return nil, err
+	}
+
+	sections := make([]domain.MenuSection, 0, len(categories)+1)
+	positions := make(map[string]int, len(categories))
+
+	for idx := range categories {
+		positions[categories[idx].ID] = idx
+		sections = append(sections, domain.MenuSection{
+			Category: &categories[idx],
+			Menus:    make([]domain.Menu, 0),
+		})
 	}
 
-	for idx, menu := range menus {
-		menus[idx].ID = enc.Encode(menu.ID)
-		menus[idx].ShopID = enc.Encode(menu.ShopID)
+	uncategorized := domain.MenuSection{Menus: make([]domain.Menu, 0)}
+
+	for _, menu := range menus {
+		idx, ok := positions[menu.CategoryID]
+		s.encodeMenu(&menu)
+
+		if ok {
+			sections[idx].Menus = append(sections[idx].Menus, menu)
+		} else {
+			uncategorized.Menus = append(uncategorized.Menus, menu)
+		}
 	}
 
-	return menus, err
+	sections = append(sections, uncategorized)
+	filled := sections[:0]
+
+	for _, section := range sections {
+		if len(section.Menus) == 0 {
+			continue
+		}
+
+		if section.Category != nil {
+			encodeCategory(section.Category)
+		}
+
+		filled = append(filled, section)
+	}
+
+	return filled, nil
 }
 
 func (s *menuServiceImpl) FetchMenuByID(params *dto.MenuParams) (*domain.Menu, error) {
@@ -55,13 +169,19 @@ func (s *menuServiceImpl) FetchMenuByID(params *dto.MenuParams) (*domain.Menu, e
 	menu, err := s.menuRepo.FetchByID(params)
 
 	if err != nil {
-		return nil, err 
+		return nil, err
 	}
 
-	menu.ID = enc.Encode(menu.ID) 
-	menu.ShopID = enc.Encode(menu.ShopID)
+	menu.Options, err = s.optionRepo.FetchByMenu(params)
 
-	return menu, err
+	if err != nil {
+		return nil, err
+	}
+
+	s.encodeMenu(menu)
+	encodeOptionGroups(menu.Options)
+
+	return menu, nil
 }
 
 func (s *menuServiceImpl) CreateMenu(params *dto.MenuParams, req *dto.MenuRequest) error {
@@ -71,15 +191,50 @@ func (s *menuServiceImpl) CreateMenu(params *dto.MenuParams, req *dto.MenuReques
 		return domain.ErrBadRequest
 	}
 
+	if _, err := uuid.Parse(decodedShopID); err != nil {
+		return domain.ErrBadRequest
+	}
 
-	err = s.menuRepo.InsertMenu(&domain.Menu{
-		Name:   req.Name,
-		ShopID: decodedShopID,
-		Price:  req.Price,
-		Status: req.Status,
-	})
+	if err := authorizeShop(s.shopRepo, decodedShopID, params.ActorID, params.ActorRole); err != nil {
+		return err
+	}
+
+	categoryID, err := s.resolveCategory(decodedShopID, req.CategoryID)
+
+	if err != nil {
+		return err
+	}
+
+	menu := &domain.Menu{
+		Name:       req.Name,
+		ShopID:     decodedShopID,
+		CategoryID: categoryID,
+		Price:      req.Price,
+		Status:     req.Status,
+		DailyStock: req.DailyStock,
+		Stock:      menuStock(req, nil),
+	}
+
+	if req.Photo != nil {
+		key, err := uploadPhoto(s.storage, "menus", req.Photo)
+
+		if err != nil {
+			return err
+		}
+
+		menu.PhotoLink = key
+	}
+
+	err = s.menuRepo.InsertMenu(menu)
 
-	return err
+	if err != nil {
+		deletePhoto(s.storage, menu.PhotoLink)
+		return err
+	}
+
+	invalidateCatalogue(s.catalogue)
+
+	return nil
 }
 
 func (s *menuServiceImpl) UpdateMenu(params *dto.MenuParams, req *dto.MenuRequest) error {
@@ -95,15 +250,110 @@ func (s *menuServiceImpl) UpdateMenu(params *dto.MenuParams, req *dto.MenuReques
 		return domain.ErrBadRequest
 	}
 
+	menu, err := s.menuRepo.FetchByID(params)
 
-	err = s.menuRepo.UpdateMenu(params, &domain.Menu{
-		Name:   req.Name,
-		ShopID: req.ShopID,
-		Price:  req.Price,
-		Status: req.Status,
-	})
+	if err != nil {
+		return err
+	}
+
+	if err := authorizeShop(s.shopRepo, menu.ShopID, params.ActorID, params.ActorRole); err != nil {
+		return err
+	}
+
+	if err := checkVersion(menu.Version, params.Versions); err != nil {
+		return err
+	}
+
+	// menus cannot move to another shop
+	if shopID, err := enc.Decode(req.ShopID); err != nil || shopID != menu.ShopID {
+		return domain.ErrBadRequest
+	}
+
+	categoryID, err := s.resolveCategory(menu.ShopID, req.CategoryID)
+
+	if err != nil {
+		return err
+	}
+
+	// keep the current photo unless a new one is uploaded
+	updated := &domain.Menu{
+		Name:       req.Name,
+		ShopID:     menu.ShopID,
+		CategoryID: categoryID,
+		Price:      req.Price,
+		Status:     req.Status,
+		DailyStock: req.DailyStock,
+		Stock:      menuStock(req, menu),
+		PhotoLink:  menu.PhotoLink,
+	}
+
+	if req.Photo != nil {
+		key, err := uploadPhoto(s.storage, "menus", req.Photo)
+
+		if err != nil {
+			return err
+		}
+
+		updated.PhotoLink = key
+	}
+
+	err = s.menuRepo.UpdateMenu(params, updated)
+
+	if err != nil {
+		if updated.PhotoLink != menu.PhotoLink {
+			deletePhoto(s.storage, updated.PhotoLink)
+		}
+
+		return versionConflict(err, params.Versions)
+	}
+
+	if updated.PhotoLink != menu.PhotoLink {
+		deletePhoto(s.storage, menu.PhotoLink)
+	}
+
+	invalidateCatalogue(s.catalogue)
+
+	return nil
+}
+
+// UpdateMenuOptions replaces all option groups of a menu. Orders placed
+// before keep their own snapshot of the chosen options.
+func (s *menuServiceImpl) UpdateMenuOptions(params *dto.MenuParams, req *dto.MenuOptionsRequest) error {
+	menuID, err := decodeID(params.ID)
+
+	if err != nil {
+		return err
+	}
+
+	params.ID = menuID
 
-	return err
+	menu, err := s.menuRepo.FetchByID(params)
+
+	if err != nil {
+		return err
+	}
+
+	if err := authorizeShop(s.shopRepo, menu.ShopID, params.ActorID, params.ActorRole); err != nil {
+		return err
+	}
+
+	if err := checkVersion(menu.Version, params.Versions); err != nil {
+		return err
+	}
+
+	groups, err := buildOptionGroups(req)
+
+	if err != nil {
+		return err
+	}
+
+	if err := s.optionRepo.ReplaceOptions(params, groups); err != nil {
+		return versionConflict(err, params.Versions)
+	}
+
+	invalidateCatalogue(s.catalogue)
+
+	return nil
 }
 
 func (s *menuServiceImpl) DeleteMenu(params *dto.MenuParams) error {
@@ -119,7 +369,156 @@ func (s *menuServiceImpl) DeleteMenu(params *dto.MenuParams) error {
 		return domain.ErrBadRequest
 	}
 
+	menu, err := s.menuRepo.FetchByID(params)
+
+	if err != nil {
+		return err
+	}
+
+	if err := authorizeShop(s.shopRepo, menu.ShopID, params.ActorID, params.ActorRole); err != nil {
+		return err
+	}
+
 	err = s.menuRepo.DeleteMenu(params)
 
-	return err
+	if err != nil {
+		return err
+	}
+
+	deletePhoto(s.storage, menu.PhotoLink)
+	invalidateCatalogue(s.catalogue)
+
+	return nil
+}
+
+// ResetDailyStock refills the stock of every stock tracked menu for the
+// current business day.
+func (s *menuServiceImpl) ResetDailyStock() error {
+	rows, err := s.menuRepo.ResetDailyStock(clock.Today())
+
+	if err != nil {
+		return err
+	}
+
+	log.Info(log.LogInfo{
+		"menus": rows,
+	}, "[MENU SERVICE][ResetDailyStock] daily stock reset")
+
+	invalidateCatalogue(s.catalogue)
+
+	return nil
+}
+
+// menuStock picks the stock left for today. A menu that starts being tracked
+// gets its full daily stock, an already tracked one keeps what is left.
+func menuStock(req *dto.MenuRequest, current *domain.Menu) *int64 {
+	if req.DailyStock == nil {
+		return nil
+	}
+
+	if req.Stock != nil {
+		return req.Stock
+	}
+
+	if current != nil && current.Stock != nil {
+		return current.Stock
+	}
+
+	stock := *req.DailyStock
+
+	return &stock
+}
+
+// decodeMenuFilter decodes the shop filter and maps the public status names
+// to the stored enum values.
+func decodeMenuFilter(params *dto.MenuParams) error {
+	if params.ShopID != "" {
+		shopID, err := decodeID(params.ShopID)
+
+		if err != nil {
+			return err
+		}
+
+		params.ShopID = shopID
+	}
+
+	if params.Filter.MinPrice != nil && params.Filter.MaxPrice != nil && *params.Filter.MinPrice > *params.Filter.MaxPrice {
+		return domain.ErrBadRequest
+	}
+
+	switch params.Filter.Status {
+	case "available":
+		params.Filter.Status = domain.MenuStatusAvailable
+	case "sold_out":
+		params.Filter.Status = domain.MenuStatusSoldOut
+	}
+
+	return nil
+}
+
+// decodeMenuPage decodes the cursor matching the requested sort, numeric
+// sort values are checked here so a tampered cursor never reaches the query.
+func decodeMenuPage(params *dto.MenuParams) error {
+	switch params.Filter.Sort {
+	case "", dto.MenuSortNewest:
+		return decodePage(&params.Page)
+	case dto.MenuSortNameAsc, dto.MenuSortNameDesc:
+		return decodeSortedPage(&params.Page)
+	}
+
+	if err := decodeSortedPage(&params.Page); err != nil {
+		return err
+	}
+
+	if params.Page.AfterID != "" {
+		if _, err := strconv.ParseInt(params.Page.AfterValue, 10, 64); err != nil {
+			return domain.ErrBadRequest
+		}
+	}
+
+	return nil
+}
+
+// resolveCategory decodes an optional category id and makes sure the
+// category belongs to the menu's shop.
+func (s *menuServiceImpl) resolveCategory(shopID, encoded string) (string, error) {
+	if encoded == "" {
+		return "", nil
+	}
+
+	categoryID, err := decodeID(encoded)
+
+	if err != nil {
+		return "", err
+	}
+
+	_, err = s.categoryRepo.FetchByID(&dto.MenuCategoryParams{
+		ID:     categoryID,
+		ShopID: shopID,
+	})
+
+	if err == domain.ErrNotFound {
+		return "", domain.ErrBadRequest
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return categoryID, nil
+}
+
+func (s *menuServiceImpl) encodeMenu(menu *domain.Menu) {
+	menu.ID = enc.Encode(menu.ID)
+	menu.ShopID = enc.Encode(menu.ShopID)
+
+	if menu.CategoryID != "" {
+		menu.CategoryID = enc.Encode(menu.CategoryID)
+	}
+
+	menu.Photos = photoVariants(s.storage, menu.PhotoLink)
+
+	if menu.PhotoLink != "" {
+		menu.PhotoLink = menu.Photos.Original
+	}
 }
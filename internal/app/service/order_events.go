@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+
+	"github.com/devanfer02/filkom-canteen/domain"
+	"github.com/devanfer02/filkom-canteen/internal/dto"
+	"github.com/devanfer02/filkom-canteen/internal/infra/env"
+	"github.com/devanfer02/filkom-canteen/internal/pkg/clock"
+	"github.com/devanfer02/filkom-canteen/internal/pkg/events"
+	"github.com/devanfer02/filkom-canteen/internal/pkg/log"
+)
+
+// SubscribeOrderEvents streams the events of the shop in params.ShopID to its
+// owners and admins, or the events of the caller's own orders when no shop
+// is given.
+func (s *orderServiceImpl) SubscribeOrderEvents(params *dto.OrderParams) (<-chan events.Event, func(), error) {
+	if params.ShopID == "" {
+		stream, unsubscribe := s.broker.Subscribe(events.UserTopic(params.ActorID))
+
+		return stream, unsubscribe, nil
+	}
+
+	if params.ActorType == env.AppEnv.JWTUserRole {
+		return nil, nil, domain.ErrForbidden
+	}
+
+	shopID, err := decodeID(params.ShopID)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := authorizeShop(s.shopRepo, shopID, params.ActorID, params.ActorRole); err != nil {
+		return nil, nil, err
+	}
+
+	stream, unsubscribe := s.broker.Subscribe(events.ShopTopic(shopID))
+
+	return stream, unsubscribe, nil
+}
+
+// publishOrder sends the current state of an order to its student and its
+// shop. Events are best effort, a failed publish never fails the change
+// that caused it.
+func (s *orderServiceImpl) publishOrder(eventType, orderID string) {
+	order, err := s.orderRepo.FetchByID(&dto.OrderParams{ID: orderID})
+
+	if err != nil {
+		return
+	}
+
+	topics := []string{events.UserTopic(order.UserID)}
+
+	if order.ShopID != "" {
+		topics = append(topics, events.ShopTopic(order.ShopID))
+	}
+
+	// the board only shows today's queue, so other days never change it
+	if order.ShopID != "" && order.QueueDate == clock.Today() {
+		s.publishBoard(order.ShopID)
+	}
+
+	s.encodeOrder(order)
+
+	for _, topic := range topics {
+		if err := s.broker.Publish(context.Background(), topic, eventType, order); err != nil {
+			log.Warn(log.LogInfo{
+				"error": err.Error(),
+				"topic": topic,
+				"type":  eventType,
+			}, "[ORDER SERVICE][publishOrder] failed to publish order event")
+		}
+	}
+}
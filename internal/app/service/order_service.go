@@ -1,42 +1,107 @@
 package service
 
 import (
+	"context"
+	"strings"
+	"time"
+
 	"github.com/devanfer02/filkom-canteen/domain"
 	"github.com/devanfer02/filkom-canteen/internal/app/repository"
 	"github.com/devanfer02/filkom-canteen/internal/dto"
+	"github.com/devanfer02/filkom-canteen/internal/infra/env"
+	"github.com/devanfer02/filkom-canteen/internal/pkg/cache"
+	"github.com/devanfer02/filkom-canteen/internal/pkg/clock"
 	enc "github.com/devanfer02/filkom-canteen/internal/pkg/encoder"
+	"github.com/devanfer02/filkom-canteen/internal/pkg/events"
+	"github.com/devanfer02/filkom-canteen/internal/pkg/storage"
 	"github.com/google/uuid"
 )
 
+const paymentProofURLExpiry = 15 * time.Minute
+
 type IOrderService interface {
-	FetchAllOrders(params *dto.OrderParams) ([]domain.Order, error)
+	FetchAllOrders(params *dto.OrderParams) ([]domain.Order, *dto.Pagination, error)
 	FetchOrderByID(params *dto.OrderParams) (*domain.Order, error)
-	CreateOrder(params *dto.OrderParams, req *dto.OrderRequest) error
-	UpdateOrder(params *dto.OrderParams, req *dto.OrderRequest) error
-	DeleteOrder(params *dto.OrderParams) error
+	FetchOrderHistory(params *dto.OrderParams) ([]domain.OrderStatusHistory, error)
+	FetchPickupSlots(params *dto.OrderParams, date string) ([]domain.PickupSlot, error)
+	CreateOrder(params *dto.OrderParams, req *dto.OrderRequest) (*domain.Order, error)
+	UpdateOrder(params *dto.OrderParams, req *dto.OrderUpdateRequest) error
+	UploadPaymentProof(params *dto.OrderParams, req *dto.PaymentProofRequest) (*domain.Order, error)
+	ApprovePayment(params *dto.OrderParams, req *dto.PaymentVerificationRequest) error
+	RejectPayment(params *dto.OrderParams, req *dto.PaymentVerificationRequest) error
+	CancelOrder(params *dto.OrderParams, req *dto.OrderCancelRequest) error
+	SubscribeOrderEvents(params *dto.OrderParams) (<-chan events.Event, func(), error)
+	FetchBoard(params *dto.OrderParams) ([]domain.BoardShop, error)
+	SubscribeBoard(params *dto.OrderParams) (<-chan events.Event, func(), error)
 }
 
 type orderServiceImpl struct {
-	orderRepo repository.IOrderRepository
+	orderRepo    repository.IOrderRepository
+	menuRepo     repository.IMenuRepository
+	optionRepo   repository.IMenuOptionRepository
+	shopRepo     repository.IShopRepository
+	scheduleRepo repository.IShopScheduleRepository
+	storage      storage.StorageInterface
+	broker       events.BrokerInterface
+	catalogue    cache.ResponseCacheInterface
 }
 
-func NewOrderService(orderRepo repository.IOrderRepository) IOrderService {
-	return &orderServiceImpl{orderRepo}
+func NewOrderService(
+	orderRepo repository.IOrderRepository,
+	menuRepo repository.IMenuRepository,
+	optionRepo repository.IMenuOptionRepository,
+	shopRepo repository.IShopRepository,
+	scheduleRepo repository.IShopScheduleRepository,
+	storage storage.StorageInterface,
+	broker events.BrokerInterface,
+	catalogue cache.ResponseCacheInterface,
+) IOrderService {
+	return &orderServiceImpl{orderRepo, menuRepo, optionRepo, shopRepo, scheduleRepo, storage, broker, catalogue}
 }
 
-func (s *orderServiceImpl) FetchAllOrders(params *dto.OrderParams) ([]domain.Order, error) {
+func (s *orderServiceImpl) FetchAllOrders(params *dto.OrderParams) ([]domain.Order, *dto.Pagination, error) {
+	if params.ShopID != "" {
+		decoded, err := enc.Decode(params.ShopID)
+
+		if err != nil {
+			return nil, nil, domain.ErrBadRequest
+		}
+
+		params.ShopID = decoded
+
+		if _, err := uuid.Parse(params.ShopID); err != nil {
+			return nil, nil, domain.ErrBadRequest
+		}
+	}
+
+	// owners only see orders of their own shops
+	if params.ActorRole == domain.RoleOwner {
+		if params.ShopID == "" {
+			params.OwnerID = params.ActorID
+		} else if err := authorizeShop(s.shopRepo, params.ShopID, params.ActorID, params.ActorRole); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if err := decodePage(&params.Page); err != nil {
+		return nil, nil, err
+	}
+
 	orders, err := s.orderRepo.FetchAll(params)
 
 	if err != nil {
-		return nil, err 
+		return nil, nil, err
 	}
 
-	for idx, order := range orders {
-		orders[idx].ID = enc.Encode(order.ID)
-		orders[idx].MenuID = enc.Encode(order.MenuID)
+	orders, pagination := paginate(orders, &params.Page, func(order *domain.Order) []string {
+		return []string{order.ID}
+	})
+
+	for idx := range orders {
+		s.encodeOrder(&orders[idx])
 	}
 
-	return orders, err
+	return orders, pagination, nil
 }
 
 func (s *orderServiceImpl) FetchOrderByID(params *dto.OrderParams) (*domain.Order, error) {
@@ -51,37 +116,247 @@ func (s *orderServiceImpl) FetchOrderByID(params *dto.OrderParams) (*domain.Orde
 	if _, err := uuid.Parse(params.ID); err != nil {
 		return nil, domain.ErrBadRequest
 	}
-	
+
 	order, err := s.orderRepo.FetchByID(params)
 
 	if err != nil {
-		return nil, err 
+		return nil, err
 	}
 
-	order.ID = enc.Encode(order.ID) 
-	order.MenuID = enc.Encode(order.MenuID)
+	if err := s.authorizeOrder(params, order); err != nil {
+		return nil, err
+	}
+
+	s.encodeOrder(order)
 
 	return order, err
 }
 
-func (s *orderServiceImpl) CreateOrder(params *dto.OrderParams, req *dto.OrderRequest) error {
-	decodedMenuID, err := enc.Decode(req.MenuID)
+func (s *orderServiceImpl) FetchOrderHistory(params *dto.OrderParams) ([]domain.OrderStatusHistory, error) {
+	decoded, err := enc.Decode(params.ID)
 
 	if err != nil {
-		return domain.ErrBadRequest
+		return nil, domain.ErrBadRequest
+	}
+
+	params.ID = decoded
+
+	if _, err := uuid.Parse(params.ID); err != nil {
+		return nil, domain.ErrBadRequest
+	}
+
+	order, err := s.orderRepo.FetchByID(params)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.authorizeOrder(params, order); err != nil {
+		return nil, err
+	}
+
+	history, err := s.orderRepo.FetchHistory(params)
+
+	if err != nil {
+		return nil, err
 	}
 
-	err = s.orderRepo.InsertOrder(&domain.Order{
-		UserID: params.UserID,
-		MenuID: decodedMenuID,
+	for idx, entry := range history {
+		history[idx].ID = enc.Encode(entry.ID)
+		history[idx].OrderID = enc.Encode(entry.OrderID)
+	}
+
+	return history, nil
+}
+
+// FetchPickupSlots lists the upcoming pre-orders of a shop for one day grouped
+// by pickup slot, starting from the current slot when the day is today.
+func (s *orderServiceImpl) FetchPickupSlots(params *dto.OrderParams, date string) ([]domain.PickupSlot, error) {
+	shopID, err := decodeID(params.ShopID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	params.ShopID = shopID
+
+	if err := authorizeShop(s.shopRepo, params.ShopID, params.ActorID, params.ActorRole); err != nil {
+		return nil, err
+	}
+
+	now := clock.Now()
+	day := now
+
+	if date != "" {
+		day, err = time.ParseInLocation(domain.DateLayout, date, clock.Location())
+
+		if err != nil {
+			return nil, domain.ErrBadRequest
+		}
+	}
+
+	year, month, dayOfMonth := day.Date()
+	params.PickupFrom = time.Date(year, month, dayOfMonth, 0, 0, 0, 0, clock.Location())
+	params.PickupTo = params.PickupFrom.AddDate(0, 0, 1)
+
+	if current := slotStart(now); current.After(params.PickupFrom) {
+		params.PickupFrom = current
+	}
+
+	if !params.PickupFrom.Before(params.PickupTo) {
+		return make([]domain.PickupSlot, 0), nil
+	}
+
+	orders, err := s.orderRepo.FetchByPickup(params)
+
+	if err != nil {
+		return nil, err
+	}
+
+	slots := groupPickupSlots(orders)
+
+	for slotIdx := range slots {
+		for idx := range slots[slotIdx].Orders {
+			s.encodeOrder(&slots[slotIdx].Orders[idx])
+		}
+
+		for idx, item := range slots[slotIdx].Items {
+			if item.MenuID != "" {
+				slots[slotIdx].Items[idx].MenuID = enc.Encode(item.MenuID)
+			}
+		}
+	}
+
+	return slots, nil
+}
+
+func (s *orderServiceImpl) CreateOrder(params *dto.OrderParams, req *dto.OrderRequest) (*domain.Order, error) {
+	order := &domain.Order{
+		UserID:        params.UserID,
 		PaymentMethod: req.PaymentMethod,
-		Status: "Waiting",
-	})
+		Status:        domain.OrderStatusWaiting,
+		Items:         make([]domain.OrderItem, 0, len(req.Items)),
+	}
+
+	ordered := make(map[string]int64, len(req.Items))
+
+	for _, item := range req.Items {
+		decodedMenuID, err := enc.Decode(item.MenuID)
+
+		if err != nil {
+			return nil, domain.ErrBadRequest
+		}
 
-	return err
+		if _, err := uuid.Parse(decodedMenuID); err != nil {
+			return nil, domain.ErrBadRequest
+		}
+
+		menu, err := s.menuRepo.FetchByID(&dto.MenuParams{ID: decodedMenuID})
+
+		if err == domain.ErrNotFound {
+			return nil, domain.ErrBadRequest
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		// a single order is paid to and prepared by a single shop
+		if order.ShopID == "" {
+			order.ShopID = menu.ShopID
+		}
+
+		if order.ShopID != menu.ShopID || menu.Status == domain.MenuStatusSoldOut {
+			return nil, domain.ErrBadRequest
+		}
+
+		// stock is only taken on acceptance, this just fails early
+		ordered[menu.ID] += item.Quantity
+
+		if menu.Stock != nil && *menu.Stock < ordered[menu.ID] {
+			return nil, domain.ErrOutOfStock
+		}
+
+		groups, err := s.optionRepo.FetchByMenu(&dto.MenuParams{ID: menu.ID})
+
+		if err != nil {
+			return nil, err
+		}
+
+		options, delta, err := selectOptions(groups, item.Options)
+
+		if err != nil {
+			return nil, err
+		}
+
+		// discounting options may lower the price, but never below zero
+		if menu.Price+delta < 0 {
+			return nil, domain.ErrBadRequest
+		}
+
+		order.Items = append(order.Items, domain.OrderItem{
+			MenuID:    menu.ID,
+			MenuName:  menu.Name,
+			Quantity:  item.Quantity,
+			UnitPrice: menu.Price + delta,
+			Notes:     item.Notes,
+			Options:   options,
+		})
+	}
+
+	// a pre-order only needs the shop to be open at pickup time
+	openAt := clock.Now()
+
+	if req.PickupAt != nil {
+		pickupAt, err := validatePickup(*req.PickupAt)
+
+		if err != nil {
+			return nil, err
+		}
+
+		order.PickupAt = &pickupAt
+		openAt = pickupAt
+	}
+
+	shop, err := ensureShopOpen(s.shopRepo, s.scheduleRepo, order.ShopID, openAt)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// pre-orders are queued on the day they are picked up
+	order.QueueDate = openAt.In(clock.Location()).Format(clock.DateLayout)
+
+	slotCapacity := 0
+
+	if shop.SlotCapacity != nil {
+		slotCapacity = *shop.SlotCapacity
+	}
+
+	err = s.orderRepo.InsertOrder(order, &domain.OrderStatusHistory{
+		ActorID:   params.UserID,
+		ActorType: params.ActorType,
+		NewStatus: order.Status,
+	}, slotCapacity)
+
+	if err != nil {
+		return nil, err
+	}
+
+	s.publishOrder(domain.OrderEventCreated, order.ID)
+
+	created, err := s.orderRepo.FetchByID(&dto.OrderParams{ID: order.ID})
+
+	if err != nil {
+		return nil, err
+	}
+
+	s.encodeOrder(created)
+
+	return created, nil
 }
 
-func (s *orderServiceImpl) UpdateOrder(params *dto.OrderParams, req *dto.OrderRequest) error {
+func (s *orderServiceImpl) UpdateOrder(params *dto.OrderParams, req *dto.OrderUpdateRequest) error {
 	decoded, err := enc.Decode(params.ID)
 
 	if err != nil {
@@ -94,17 +369,261 @@ func (s *orderServiceImpl) UpdateOrder(params *dto.OrderParams, req *dto.OrderRe
 		return domain.ErrBadRequest
 	}
 
+	if !domain.IsOrderStatus(req.Status) {
+		return domain.ErrBadRequest
+	}
+
+	order, err := s.orderRepo.FetchByID(params)
+
+	if err != nil {
+		return err
+	}
+
+	if err := authorizeShop(s.shopRepo, order.ShopID, params.ActorID, params.ActorRole); err != nil {
+		return err
+	}
+
+	if err := checkVersion(order.Version, params.Versions); err != nil {
+		return err
+	}
+
+	if req.Status == domain.OrderStatusCancelled {
+		return s.cancel(params, order, req.Reason)
+	}
+
+	if !domain.CanTransitionOrder(order.Status, req.Status) {
+		return domain.ErrInvalidStatusTransition
+	}
+
+	// QRIS orders are only accepted through payment approval
+	if req.Status == domain.OrderStatusAccepted &&
+		order.PaymentMethod == domain.PaymentMethodQRIS &&
+		order.PaymentStatus != domain.PaymentStatusApproved {
+		return domain.ErrInvalidPaymentState
+	}
+
+	if req.PaymentMethod != "" {
+		order.PaymentMethod = req.PaymentMethod
+	}
+
+	params.Status = order.Status
 
 	err = s.orderRepo.UpdateOrder(params, &domain.Order{
-		Status:           req.Status,
-		PaymentMethod:    req.PaymentMethod,
-		PaymentProofLink: "",
+		Status:        req.Status,
+		PaymentMethod: order.PaymentMethod,
+	}, &domain.OrderStatusHistory{
+		ActorID:   params.ActorID,
+		ActorType: params.ActorType,
+		OldStatus: order.Status,
+		NewStatus: req.Status,
+		Reason:    req.Reason,
+	})
+
+	// the order exists, so no affected rows means it changed meanwhile
+	if err == domain.ErrNotFound {
+		if len(params.Versions) > 0 {
+			return domain.ErrPreconditionFailed
+		}
+
+		return domain.ErrInvalidStatusTransition
+	}
+
+	if err != nil {
+		return err
+	}
+
+	// accepting an order takes its stock, which public menus show
+	if req.Status == domain.OrderStatusAccepted {
+		invalidateCatalogue(s.catalogue)
+	}
+
+	s.publishOrder(domain.OrderEventStatusChanged, params.ID)
+
+	return nil
+}
+
+func (s *orderServiceImpl) UploadPaymentProof(params *dto.OrderParams, req *dto.PaymentProofRequest) (*domain.Order, error) {
+	decoded, err := enc.Decode(params.ID)
+
+	if err != nil {
+		return nil, domain.ErrBadRequest
+	}
+
+	params.ID = decoded
+
+	if _, err := uuid.Parse(params.ID); err != nil {
+		return nil, domain.ErrBadRequest
+	}
+
+	order, err := s.orderRepo.FetchByID(params)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if order.UserID != params.UserID {
+		return nil, domain.ErrForbidden
+	}
+
+	if order.PaymentMethod != domain.PaymentMethodQRIS {
+		return nil, domain.ErrBadRequest
+	}
+
+	if order.Status != domain.OrderStatusWaiting || order.PaymentStatus == domain.PaymentStatusApproved {
+		return nil, domain.ErrInvalidPaymentState
+	}
+
+	key, err := uploadImage(s.storage, "payments/"+order.ID, req.PaymentProofFile)
+
+	if err != nil {
+		return nil, err
+	}
+
+	params.Status = domain.OrderStatusWaiting
+	params.PaymentStatus = order.PaymentStatus
+
+	err = s.orderRepo.UpdatePaymentProof(params, &domain.Order{
+		PaymentProofLink: key,
+		PaymentStatus:    domain.PaymentStatusSubmitted,
 	})
 
-	return err
+	if err != nil {
+		deleteStoredFile(s.storage, key)
+
+		if err == domain.ErrNotFound {
+			return nil, domain.ErrInvalidPaymentState
+		}
+
+		return nil, err
+	}
+
+	if order.PaymentProofLink != "" {
+		deleteStoredFile(s.storage, order.PaymentProofLink)
+	}
+
+	s.publishOrder(domain.OrderEventPaymentUpdated, params.ID)
+
+	updated, err := s.orderRepo.FetchByID(params)
+
+	if err != nil {
+		return nil, err
+	}
+
+	s.encodeOrder(updated)
+
+	return updated, nil
+}
+
+func (s *orderServiceImpl) ApprovePayment(params *dto.OrderParams, req *dto.PaymentVerificationRequest) error {
+	order, err := s.fetchForVerification(params)
+
+	if err != nil {
+		return err
+	}
+
+	if !domain.CanTransitionOrder(order.Status, domain.OrderStatusAccepted) {
+		return domain.ErrInvalidStatusTransition
+	}
+
+	params.Status = order.Status
+	params.PaymentStatus = domain.PaymentStatusSubmitted
+
+	err = s.orderRepo.VerifyPayment(params, &domain.Order{
+		Status:        domain.OrderStatusAccepted,
+		PaymentStatus: domain.PaymentStatusApproved,
+		PaymentNote:   req.Reason,
+	}, &domain.OrderStatusHistory{
+		ActorID:   params.ActorID,
+		ActorType: params.ActorType,
+		OldStatus: order.Status,
+		NewStatus: domain.OrderStatusAccepted,
+		Reason:    "payment approved",
+	})
+
+	if err == domain.ErrNotFound {
+		return domain.ErrInvalidPaymentState
+	}
+
+	if err != nil {
+		return err
+	}
+
+	invalidateCatalogue(s.catalogue)
+	s.publishOrder(domain.OrderEventStatusChanged, params.ID)
+
+	return nil
+}
+
+func (s *orderServiceImpl) RejectPayment(params *dto.OrderParams, req *dto.PaymentVerificationRequest) error {
+	req.Reason = strings.TrimSpace(req.Reason)
+
+	if req.Reason == "" {
+		return domain.ErrBadRequest
+	}
+
+	order, err := s.fetchForVerification(params)
+
+	if err != nil {
+		return err
+	}
+
+	params.Status = order.Status
+	params.PaymentStatus = domain.PaymentStatusSubmitted
+
+	// the order stays as is and waits for the student to upload a new proof
+	err = s.orderRepo.VerifyPayment(params, &domain.Order{
+		Status:        order.Status,
+		PaymentStatus: domain.PaymentStatusRejected,
+		PaymentNote:   req.Reason,
+	}, nil)
+
+	if err == domain.ErrNotFound {
+		return domain.ErrInvalidPaymentState
+	}
+
+	if err != nil {
+		return err
+	}
+
+	s.publishOrder(domain.OrderEventPaymentUpdated, params.ID)
+
+	return nil
+}
+
+func (s *orderServiceImpl) fetchForVerification(params *dto.OrderParams) (*domain.Order, error) {
+	decoded, err := enc.Decode(params.ID)
+
+	if err != nil {
+		return nil, domain.ErrBadRequest
+	}
+
+	params.ID = decoded
+
+	if _, err := uuid.Parse(params.ID); err != nil {
+		return nil, domain.ErrBadRequest
+	}
+
+	order, err := s.orderRepo.FetchByID(params)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeShop(s.shopRepo, order.ShopID, params.ActorID, params.ActorRole); err != nil {
+		return nil, err
+	}
+
+	if order.PaymentStatus != domain.PaymentStatusSubmitted {
+		return nil, domain.ErrInvalidPaymentState
+	}
+
+	return order, nil
 }
 
-func (s *orderServiceImpl) DeleteOrder(params *dto.OrderParams) error {
+// CancelOrder lets students cancel their own order while it still waits for
+// the shop, and owners cancel any order of their shop before pickup as long
+// as they say why.
+func (s *orderServiceImpl) CancelOrder(params *dto.OrderParams, req *dto.OrderCancelRequest) error {
 	decoded, err := enc.Decode(params.ID)
 
 	if err != nil {
@@ -117,7 +636,122 @@ func (s *orderServiceImpl) DeleteOrder(params *dto.OrderParams) error {
 		return domain.ErrBadRequest
 	}
 
-	err = s.orderRepo.DeleteOrder(params)
+	order, err := s.orderRepo.FetchByID(params)
+
+	if err != nil {
+		return err
+	}
 
-	return err
+	if params.ActorType == env.AppEnv.JWTUserRole {
+		if order.UserID != params.ActorID {
+			return domain.ErrForbidden
+		}
+
+		if order.Status != domain.OrderStatusWaiting {
+			return domain.ErrInvalidStatusTransition
+		}
+	} else if err := authorizeShop(s.shopRepo, order.ShopID, params.ActorID, params.ActorRole); err != nil {
+		return err
+	}
+
+	return s.cancel(params, order, req.Reason)
+}
+
+// cancel runs the shared part of a cancellation for an already authorized
+// actor, an update to Cancelled through UpdateOrder ends up here as well.
+func (s *orderServiceImpl) cancel(params *dto.OrderParams, order *domain.Order, reason string) error {
+	reason = strings.TrimSpace(reason)
+
+	if params.ActorType != env.AppEnv.JWTUserRole && reason == "" {
+		return domain.ErrBadRequest
+	}
+
+	if !domain.CanTransitionOrder(order.Status, domain.OrderStatusCancelled) {
+		return domain.ErrInvalidStatusTransition
+	}
+
+	now := time.Now()
+	params.Status = order.Status
+
+	err := s.orderRepo.CancelOrder(params, &domain.Order{
+		CancelledAt:     &now,
+		CancelledBy:     params.ActorID,
+		CancelledByType: params.ActorType,
+		CancelReason:    reason,
+	}, &domain.OrderStatusHistory{
+		ActorID:   params.ActorID,
+		ActorType: params.ActorType,
+		OldStatus: order.Status,
+		NewStatus: domain.OrderStatusCancelled,
+		Reason:    reason,
+	})
+
+	// the order exists, so no affected rows means it changed meanwhile
+	if err == domain.ErrNotFound {
+		if len(params.Versions) > 0 {
+			return domain.ErrPreconditionFailed
+		}
+
+		return domain.ErrInvalidStatusTransition
+	}
+
+	if err != nil {
+		return err
+	}
+
+	// the cancelled order gave its stock back to the menus
+	if domain.OrderHoldsStock(order.Status) {
+		invalidateCatalogue(s.catalogue)
+	}
+
+	s.publishOrder(domain.OrderEventStatusChanged, params.ID)
+
+	return nil
+}
+
+// authorizeOrder lets students read only their own orders, while admin tokens
+// go through the shop ownership check of the order's shop.
+func (s *orderServiceImpl) authorizeOrder(params *dto.OrderParams, order *domain.Order) error {
+	if params.ActorType == env.AppEnv.JWTUserRole {
+		if order.UserID != params.ActorID {
+			return domain.ErrForbidden
+		}
+
+		return nil
+	}
+
+	return authorizeShop(s.shopRepo, order.ShopID, params.ActorID, params.ActorRole)
+}
+
+func (s *orderServiceImpl) encodeOrder(order *domain.Order) {
+	order.ID = enc.Encode(order.ID)
+
+	if order.PaymentProofLink != "" {
+		// payment proofs are private, only hand out short lived links
+		link, err := s.storage.SignedURL(context.Background(), order.PaymentProofLink, paymentProofURLExpiry)
+
+		if err != nil {
+			link = ""
+		}
+
+		order.PaymentProofLink = link
+	}
+
+	if order.ShopID != "" {
+		order.ShopID = enc.Encode(order.ShopID)
+	}
+
+	for idx, item := range order.Items {
+		order.Items[idx].ID = enc.Encode(item.ID)
+
+		if item.MenuID != "" {
+			order.Items[idx].MenuID = enc.Encode(item.MenuID)
+		}
+
+		for optionIdx, option := range item.Options {
+			if option.ValueID != "" {
+				order.Items[idx].Options[optionIdx].ValueID = enc.Encode(option.ValueID)
+			}
+		}
+	}
 }
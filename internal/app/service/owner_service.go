@@ -10,7 +10,7 @@ import (
 )
 
 type IOwnerService interface {
-	FetchAllOwners() ([]domain.Owner, error)
+	FetchAllOwners(params *dto.OwnerParams) ([]domain.Owner, *dto.Pagination, error)
 	FetchOwnerByID(params *dto.OwnerParams) (*domain.Owner, error)
 	CreateOwner(req *dto.OwnerRequest) error
 	UpdateOwner(params *dto.OwnerParams, req *dto.OwnerRequest) error
@@ -25,10 +25,22 @@ func NewOwnerService(ownerRepo repository.IOwnerRepository) IOwnerService {
 	return &ownerServiceImpl{ownerRepo}
 }
 
-func (s *ownerServiceImpl) FetchAllOwners() ([]domain.Owner, error) {
-	owners, err := s.ownerRepo.FetchAll(&dto.OwnerParams{})
+func (s *ownerServiceImpl) FetchAllOwners(params *dto.OwnerParams) ([]domain.Owner, *dto.Pagination, error) {
+	if err := decodePage(&params.Page); err != nil {
+		return nil, nil, err
+	}
+
+	owners, err := s.ownerRepo.FetchAll(params)
+
+	if err != nil {
+		return nil, nil, err
+	}
 
-	return owners, err
+	owners, pagination := paginate(owners, &params.Page, func(owner *domain.Owner) []string {
+		return []string{owner.ID}
+	})
+
+	return owners, pagination, nil
 }
 
 func (s *ownerServiceImpl) FetchOwnerByID(params *dto.OwnerParams) (*domain.Owner, error) {
@@ -70,6 +82,10 @@ func (s *ownerServiceImpl) UpdateOwner(params *dto.OwnerParams, req *dto.OwnerRe
 		err error
 	)
 
+	if err = authorizeOwner(params.ID, params.ActorID, params.ActorRole); err != nil {
+		return err
+	}
+
 	if req.Password != "" {
 		req.Password, err = bcrypt.HashPassword(req.Password)
 
@@ -81,6 +97,19 @@ func (s *ownerServiceImpl) UpdateOwner(params *dto.OwnerParams, req *dto.OwnerRe
 		}
 	}
 
+	// tells a stale version apart from a missing owner
+	if len(params.Versions) > 0 {
+		owner, err := s.ownerRepo.FetchByID(params)
+
+		if err != nil {
+			return err
+		}
+
+		if err := checkVersion(owner.Version, params.Versions); err != nil {
+			return err
+		}
+	}
+
 	err = s.ownerRepo.UpdateOwner(params, &domain.Owner{
 		Fullname: req.Fullname,
 		Username: req.Username,
@@ -88,7 +117,7 @@ func (s *ownerServiceImpl) UpdateOwner(params *dto.OwnerParams, req *dto.OwnerRe
 		WANumber: req.WANumber,
 	})
 
-	return err 
+	return versionConflict(err, params.Versions)
 }
 
 func (s *ownerServiceImpl) DeleteOwner(params *dto.OwnerParams) error {
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"github.com/devanfer02/filkom-canteen/domain"
+	"github.com/devanfer02/filkom-canteen/internal/dto"
+	enc "github.com/devanfer02/filkom-canteen/internal/pkg/encoder"
+	"github.com/google/uuid"
+)
+
+// decodePage applies the default and maximum page size and decodes the
+// cursor sent by the client.
+func decodePage(page *dto.PageParams) error {
+	return decodeCursor(page, false)
+}
+
+// decodeSortedPage is decodePage for listings sorted by another column than
+// id, their cursor carries the sort value of the last row before its id.
+func decodeSortedPage(page *dto.PageParams) error {
+	return decodeCursor(page, true)
+}
+
+func decodeCursor(page *dto.PageParams, withValue bool) error {
+	if page.Limit < 1 {
+		page.Limit = dto.DefaultPageLimit
+	}
+
+	if page.Limit > dto.MaxPageLimit {
+		page.Limit = dto.MaxPageLimit
+	}
+
+	if page.Cursor == "" {
+		return nil
+	}
+
+	size := 1
+
+	if withValue {
+		size = 2
+	}
+
+	values, err := enc.DecodeCursor(page.Cursor)
+
+	if err != nil || len(values) != size {
+		return domain.ErrBadRequest
+	}
+
+	page.AfterID = values[size-1]
+
+	if _, err := uuid.Parse(page.AfterID); err != nil {
+		return domain.ErrBadRequest
+	}
+
+	if withValue {
+		page.AfterValue = values[0]
+	}
+
+	return nil
+}
+
+// paginate trims the extra row fetched by the repository and builds the
+// cursor of the next page from the keyset values of the last row, it must run
+// before ids are encoded.
+func paginate[T any](items []T, page *dto.PageParams, cursor func(item *T) []string) ([]T, *dto.Pagination) {
+	pagination := &dto.Pagination{Limit: page.Limit}
+
+	if len(items) > page.Limit {
+		items = items[:page.Limit]
+		pagination.HasMore = true
+		pagination.NextCursor = enc.EncodeCursor(cursor(&items[len(items)-1])...)
+	}
+
+	return items, pagination
+}
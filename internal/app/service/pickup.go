@@ -0,0 +1,103 @@
+package service
+
+import (
+	"strings"
+	"time"
+
+	"github.com/devanfer02/filkom-canteen/domain"
+	"github.com/devanfer02/filkom-canteen/internal/infra/env"
+	"github.com/devanfer02/filkom-canteen/internal/pkg/clock"
+)
+
+const (
+	defaultSlotMinutes  = 15
+	defaultPreorderDays = 7
+)
+
+func slotLength() time.Duration {
+	minutes := env.AppEnv.SlotMinutes
+
+	if minutes < 1 {
+		minutes = defaultSlotMinutes
+	}
+
+	return time.Duration(minutes) * time.Minute
+}
+
+// slotStart floors a time to the start of its pickup slot, slots are counted
+// from midnight in the canteen timezone.
+func slotStart(at time.Time) time.Time {
+	at = at.In(clock.Location())
+	year, month, day := at.Date()
+	midnight := time.Date(year, month, day, 0, 0, 0, 0, at.Location())
+
+	return midnight.Add(at.Sub(midnight).Truncate(slotLength()))
+}
+
+// validatePickup accepts a requested pickup time only when it is the start of
+// a slot in the future and within the pre-order window.
+func validatePickup(at time.Time) (time.Time, error) {
+	at = at.In(clock.Location())
+	now := clock.Now()
+
+	days := env.AppEnv.PreorderDays
+
+	if days < 1 {
+		days = defaultPreorderDays
+	}
+
+	if !slotStart(at).Equal(at) || !at.After(now) || at.After(now.AddDate(0, 0, days)) {
+		return time.Time{}, domain.ErrBadRequest
+	}
+
+	return at, nil
+}
+
+// groupPickupSlots splits orders sorted by pickup time into their slots and
+// totals what has to be prepared for each slot.
+func groupPickupSlots(orders []domain.Order) []domain.PickupSlot {
+	slots := make([]domain.PickupSlot, 0)
+	positions := make(map[string]int)
+
+	for _, order := range orders {
+		start := slotStart(*order.PickupAt)
+
+		if len(slots) == 0 || !slots[len(slots)-1].Start.Equal(start) {
+			slots = append(slots, domain.PickupSlot{
+				Start:  start,
+				End:    start.Add(slotLength()),
+				Items:  make([]domain.PickupSlotItem, 0),
+				Orders: make([]domain.Order, 0),
+			})
+			clear(positions)
+		}
+
+		slot := &slots[len(slots)-1]
+		slot.Orders = append(slot.Orders, order)
+
+		for _, item := range order.Items {
+			options := make([]string, len(item.Options))
+
+			for idx, option := range item.Options {
+				options[idx] = option.ValueName
+			}
+
+			key := item.MenuID + "|" + item.MenuName + "|" + strings.Join(options, "|")
+			idx, ok := positions[key]
+
+			if !ok {
+				idx = len(slot.Items)
+				positions[key] = idx
+				slot.Items = append(slot.Items, domain.PickupSlotItem{
+					MenuID:   item.MenuID,
+					MenuName: item.MenuName,
+					Options:  options,
+				})
+			}
+
+			slot.Items[idx].Quantity += item.Quantity
+		}
+	}
+
+	return slots
+}
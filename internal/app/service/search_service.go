@@ -0,0 +1,125 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/devanfer02/filkom-canteen/domain"
+	"github.com/devanfer02/filkom-canteen/internal/app/repository"
+	"github.com/devanfer02/filkom-canteen/internal/dto"
+	enc "github.com/devanfer02/filkom-canteen/internal/pkg/encoder"
+	"github.com/devanfer02/filkom-canteen/internal/pkg/storage"
+)
+
+type ISearchService interface {
+	Search(params *dto.SearchParams) ([]domain.SearchResult, error)
+}
+
+type searchServiceImpl struct {
+	searchRepo   repository.ISearchRepository
+	scheduleRepo repository.IShopScheduleRepository
+	storage      storage.StorageInterface
+}
+
+func NewSearchService(
+	searchRepo repository.ISearchRepository,
+	scheduleRepo repository.IShopScheduleRepository,
+	storage storage.StorageInterface,
+) ISearchService {
+	return &searchServiceImpl{searchRepo, scheduleRepo, storage}
+}
+
+func (s *searchServiceImpl) Search(params *dto.SearchParams) ([]domain.SearchResult, error) {
+	params.Query = strings.TrimSpace(params.Query)
+
+	if len([]rune(params.Query)) < 2 {
+		return nil, domain.ErrBadRequest
+	}
+
+	if params.Limit < 1 {
+		params.Limit = dto.DefaultSearchLimit
+	}
+
+	if params.Limit > dto.MaxSearchLimit {
+		params.Limit = dto.MaxSearchLimit
+	}
+
+	shops, err := s.searchRepo.SearchShops(params)
+
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]domain.SearchResult, len(shops))
+
+	if len(shops) == 0 {
+		return results, nil
+	}
+
+	shopIDs := make([]string, len(shops))
+	positions := make(map[string]int, len(shops))
+
+	for idx, hit := range shops {
+		shopIDs[idx] = hit.ID
+		positions[hit.ID] = idx
+
+		results[idx] = domain.SearchResult{
+			Shop:  hit.Shop,
+			Score: hit.Score,
+			Menus: make([]domain.SearchMenuHit, 0),
+		}
+	}
+
+	menus, err := s.searchRepo.SearchMenus(params, shopIDs)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// menus arrive ranked, so each group keeps the best hits first
+	for _, hit := range menus {
+		idx, ok := positions[hit.ShopID]
+
+		if !ok {
+			continue
+		}
+
+		results[idx].Menus = append(results[idx].Menus, hit)
+	}
+
+	refs := make([]*domain.Shop, len(results))
+
+	for idx := range results {
+		refs[idx] = &results[idx].Shop
+	}
+
+	if err := applyOpenState(s.scheduleRepo, refs); err != nil {
+		return nil, err
+	}
+
+	for idx := range results {
+		s.encodeResult(&results[idx])
+	}
+
+	return results, nil
+}
+
+func (s *searchServiceImpl) encodeResult(result *domain.SearchResult) {
+	result.Shop.ID = enc.Encode(result.Shop.ID)
+	result.Shop.Photos = photoVariants(s.storage, result.Shop.PhotoLink)
+
+	if result.Shop.Photos != nil {
+		result.Shop.PhotoLink = result.Shop.Photos.Original
+	}
+
+	for idx := range result.Menus {
+		menu := &result.Menus[idx].Menu
+
+		menu.ID = enc.Encode(menu.ID)
+		menu.ShopID = result.Shop.ID
+		menu.Photos = photoVariants(s.storage, menu.PhotoLink)
+
+		if menu.Photos != nil {
+			menu.PhotoLink = menu.Photos.Original
+		}
+	}
+}
@@ -0,0 +1,305 @@
+package service
+
+import (
+	"strings"
+	"time"
+
+	"github.com/devanfer02/filkom-canteen/domain"
+	"github.com/devanfer02/filkom-canteen/internal/app/repository"
+	"github.com/devanfer02/filkom-canteen/internal/dto"
+	"github.com/devanfer02/filkom-canteen/internal/pkg/cache"
+	"github.com/devanfer02/filkom-canteen/internal/pkg/clock"
+	enc "github.com/devanfer02/filkom-canteen/internal/pkg/encoder"
+)
+
+// upcomingClosureDays is how far ahead closures are listed on a shop.
+const upcomingClosureDays = 30
+
+type IShopScheduleService interface {
+	UpdateHours(params *dto.ShopScheduleParams, req *dto.ShopHoursRequest) error
+	AddClosure(params *dto.ShopScheduleParams, req *dto.ShopClosureRequest) (*domain.ShopClosure, error)
+	RemoveClosure(params *dto.ShopScheduleParams) error
+	UpdateStatus(params *dto.ShopScheduleParams, req *dto.ShopStatusRequest) error
+}
+
+type shopScheduleServiceImpl struct {
+	scheduleRepo repository.IShopScheduleRepository
+	shopRepo     repository.IShopRepository
+	catalogue    cache.ResponseCacheInterface
+}
+
+func NewShopScheduleService(
+	scheduleRepo repository.IShopScheduleRepository,
+	shopRepo repository.IShopRepository,
+	catalogue cache.ResponseCacheInterface,
+) IShopScheduleService {
+	return &shopScheduleServiceImpl{scheduleRepo, shopRepo, catalogue}
+}
+
+func (s *shopScheduleServiceImpl) UpdateHours(params *dto.ShopScheduleParams, req *dto.ShopHoursRequest) error {
+	shop, err := s.authorize(params)
+
+	if err != nil {
+		return err
+	}
+
+	if err := checkVersion(shop.Version, params.Versions); err != nil {
+		return err
+	}
+
+	hours := make([]domain.ShopHours, 0, len(req.Hours))
+
+	for _, item := range req.Hours {
+		openTime, err := time.Parse(domain.TimeOfDayLayout, item.OpenTime)
+
+		if err != nil {
+			return domain.ErrBadRequest
+		}
+
+		closeTime, err := time.Parse(domain.TimeOfDayLayout, item.CloseTime)
+
+		if err != nil || !openTime.Before(closeTime) {
+			return domain.ErrBadRequest
+		}
+
+		// formatted again so "7:30" compares correctly against "07:30"
+		hours = append(hours, domain.ShopHours{
+			Weekday:   *item.Weekday,
+			OpenTime:  openTime.Format(domain.TimeOfDayLayout),
+			CloseTime: closeTime.Format(domain.TimeOfDayLayout),
+		})
+	}
+
+	if err := s.scheduleRepo.ReplaceHours(params, hours); err != nil {
+		return versionConflict(err, params.Versions)
+	}
+
+	invalidateCatalogue(s.catalogue)
+
+	return nil
+}
+
+func (s *shopScheduleServiceImpl) AddClosure(params *dto.ShopScheduleParams, req *dto.ShopClosureRequest) (*domain.ShopClosure, error) {
+	if _, err := s.authorize(params); err != nil {
+		return nil, err
+	}
+
+	date, err := time.ParseInLocation(domain.DateLayout, req.Date, clock.Location())
+
+	if err != nil {
+		return nil, domain.ErrBadRequest
+	}
+
+	if date.Format(domain.DateLayout) < clock.Today() {
+		return nil, domain.ErrBadRequest
+	}
+
+	closure := &domain.ShopClosure{
+		ShopID: params.ShopID,
+		Date:   date.Format(domain.DateLayout),
+		Reason: strings.TrimSpace(req.Reason),
+	}
+
+	if err := s.scheduleRepo.InsertClosure(closure); err != nil {
+		return nil, err
+	}
+
+	invalidateCatalogue(s.catalogue)
+
+	closure.ID = enc.Encode(closure.ID)
+
+	return closure, nil
+}
+
+func (s *shopScheduleServiceImpl) RemoveClosure(params *dto.ShopScheduleParams) error {
+	closureID, err := decodeID(params.ClosureID)
+
+	if err != nil {
+		return err
+	}
+
+	params.ClosureID = closureID
+
+	if _, err := s.authorize(params); err != nil {
+		return err
+	}
+
+	if err := s.scheduleRepo.DeleteClosure(params); err != nil {
+		return err
+	}
+
+	invalidateCatalogue(s.catalogue)
+
+	return nil
+}
+
+// UpdateStatus closes a shop right now regardless of its hours, or lifts
+// that override again.
+func (s *shopScheduleServiceImpl) UpdateStatus(params *dto.ShopScheduleParams, req *dto.ShopStatusRequest) error {
+	shop, err := s.authorize(params)
+
+	if err != nil {
+		return err
+	}
+
+	if err := checkVersion(shop.Version, params.Versions); err != nil {
+		return err
+	}
+
+	updated := &domain.Shop{ManuallyClosed: *req.Closed}
+
+	if updated.ManuallyClosed {
+		updated.ClosedReason = strings.TrimSpace(req.Reason)
+	}
+
+	err = s.shopRepo.UpdateShopStatus(&dto.ShopParams{
+		ID:       params.ShopID,
+		Versions: params.Versions,
+	}, updated)
+
+	if err != nil {
+		return versionConflict(err, params.Versions)
+	}
+
+	invalidateCatalogue(s.catalogue)
+
+	return nil
+}
+
+// authorize decodes the shop of the params and returns the shop once the
+// actor may manage it.
+func (s *shopScheduleServiceImpl) authorize(params *dto.ShopScheduleParams) (*domain.Shop, error) {
+	shopID, err := decodeID(params.ShopID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	params.ShopID = shopID
+
+	shop, err := s.shopRepo.FetchShopByID(&dto.ShopParams{ID: shopID})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeShop(s.shopRepo, shopID, params.ActorID, params.ActorRole); err != nil {
+		return nil, err
+	}
+
+	return shop, nil
+}
+
+// applyOpenState fills in whether each shop is open right now, using one
+// query for the hours and one for today's closures of all shops.
+func applyOpenState(scheduleRepo repository.IShopScheduleRepository, shops []*domain.Shop) error {
+	if len(shops) == 0 {
+		return nil
+	}
+
+	shopIDs := make([]string, len(shops))
+
+	for idx, shop := range shops {
+		shopIDs[idx] = shop.ID
+	}
+
+	now := clock.Now()
+	today := now.Format(domain.DateLayout)
+
+	hours, err := scheduleRepo.FetchHours(shopIDs)
+
+	if err != nil {
+		return err
+	}
+
+	closures, err := scheduleRepo.FetchClosures(shopIDs, today, today)
+
+	if err != nil {
+		return err
+	}
+
+	hoursByShop := make(map[string][]domain.ShopHours, len(shops))
+	closuresByShop := make(map[string][]domain.ShopClosure, len(closures))
+
+	for _, interval := range hours {
+		hoursByShop[interval.ShopID] = append(hoursByShop[interval.ShopID], interval)
+	}
+
+	for _, closure := range closures {
+		closuresByShop[closure.ShopID] = append(closuresByShop[closure.ShopID], closure)
+	}
+
+	for _, shop := range shops {
+		shop.IsOpen = shop.OpenAt(hoursByShop[shop.ID], closuresByShop[shop.ID], now, now)
+	}
+
+	return nil
+}
+
+// loadSchedule attaches the weekly hours and upcoming closures to a shop and
+// works out whether it is open right now.
+func loadSchedule(scheduleRepo repository.IShopScheduleRepository, shop *domain.Shop) error {
+	now := clock.Now()
+
+	hours, err := scheduleRepo.FetchHours([]string{shop.ID})
+
+	if err != nil {
+		return err
+	}
+
+	closures, err := scheduleRepo.FetchClosures(
+		[]string{shop.ID},
+		now.Format(domain.DateLayout),
+		now.AddDate(0, 0, upcomingClosureDays).Format(domain.DateLayout),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	shop.Hours = hours
+	shop.Closures = closures
+	shop.IsOpen = shop.OpenAt(hours, closures, now, now)
+
+	for idx := range shop.Closures {
+		shop.Closures[idx].ID = enc.Encode(shop.Closures[idx].ID)
+	}
+
+	return nil
+}
+
+// ensureShopOpen fails with ErrShopClosed unless the shop takes orders at the
+// given time, otherwise it returns the shop.
+func ensureShopOpen(
+	shopRepo repository.IShopRepository,
+	scheduleRepo repository.IShopScheduleRepository,
+	shopID string,
+	at time.Time,
+) (*domain.Shop, error) {
+	shop, err := shopRepo.FetchShopByID(&dto.ShopParams{ID: shopID})
+
+	if err != nil {
+		return nil, err
+	}
+
+	at = at.In(clock.Location())
+	date := at.Format(domain.DateLayout)
+
+	hours, err := scheduleRepo.FetchHours([]string{shopID})
+
+	if err != nil {
+		return nil, err
+	}
+
+	closures, err := scheduleRepo.FetchClosures([]string{shopID}, date, date)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !shop.OpenAt(hours, closures, at, clock.Now()) {
+		return nil, domain.ErrShopClosed
+	}
+
+	return shop, nil
+}
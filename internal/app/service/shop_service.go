@@ -4,12 +4,14 @@ import (
 	"github.com/devanfer02/filkom-canteen/domain"
 	"github.com/devanfer02/filkom-canteen/internal/app/repository"
 	"github.com/devanfer02/filkom-canteen/internal/dto"
+	"github.com/devanfer02/filkom-canteen/internal/pkg/cache"
 	enc "github.com/devanfer02/filkom-canteen/internal/pkg/encoder"
+	"github.com/devanfer02/filkom-canteen/internal/pkg/storage"
 	"github.com/google/uuid"
 )
 
 type IShopService interface {
-	FetchAllShops() ([]domain.Shop, error)
+	FetchAllShops(params *dto.ShopParams) ([]domain.Shop, *dto.Pagination, error)
 	FetchShopByID(params *dto.ShopParams) (*domain.Shop, error)
 	CreateShop(req *dto.ShopRequest) error
 	AddOwner(req *dto.ShopParams) error
@@ -19,25 +21,51 @@ type IShopService interface {
 }
 
 type shopServiceImpl struct {
-	shopRepo repository.IShopRepository
+	shopRepo     repository.IShopRepository
+	scheduleRepo repository.IShopScheduleRepository
+	storage      storage.StorageInterface
+	catalogue    cache.ResponseCacheInterface
 }
 
-func NewShopService(shopRepo repository.IShopRepository) IShopService {
-	return &shopServiceImpl{shopRepo: shopRepo}
+func NewShopService(
+	shopRepo repository.IShopRepository,
+	scheduleRepo repository.IShopScheduleRepository,
+	storage storage.StorageInterface,
+	catalogue cache.ResponseCacheInterface,
+) IShopService {
+	return &shopServiceImpl{shopRepo: shopRepo, scheduleRepo: scheduleRepo, storage: storage, catalogue: catalogue}
 }
 
-func (s *shopServiceImpl) FetchAllShops() ([]domain.Shop, error) {
-	shops, err := s.shopRepo.FetchAllShops()
+func (s *shopServiceImpl) FetchAllShops(params *dto.ShopParams) ([]domain.Shop, *dto.Pagination, error) {
+	if err := decodePage(&params.Page); err != nil {
+		return nil, nil, err
+	}
+
+	shops, err := s.shopRepo.FetchAllShops(params)
 
 	if err != nil {
-		return nil, err 
+		return nil, nil, err
+	}
+
+	shops, pagination := paginate(shops, &params.Page, func(shop *domain.Shop) []string {
+		return []string{shop.ID}
+	})
+
+	refs := make([]*domain.Shop, len(shops))
+
+	for idx := range shops {
+		refs[idx] = &shops[idx]
+	}
+
+	if err := applyOpenState(s.scheduleRepo, refs); err != nil {
+		return nil, nil, err
 	}
 
-	for idx, shop := range shops {
-		shops[idx].ID = enc.Encode(shop.ID)
+	for idx := range shops {
+		s.encodeShop(&shops[idx])
 	}
 
-	return shops, err
+	return shops, pagination, nil
 }
 
 func (s *shopServiceImpl) FetchShopByID(params *dto.ShopParams) (*domain.Shop, error) {
@@ -56,22 +84,45 @@ func (s *shopServiceImpl) FetchShopByID(params *dto.ShopParams) (*domain.Shop, e
 	shop, err := s.shopRepo.FetchShopByID(params)
 
 	if err != nil {
-		return nil, err 
+		return nil, err
+	}
+
+	if err := loadSchedule(s.scheduleRepo, shop); err != nil {
+		return nil, err
 	}
 
-	shop.ID = enc.Encode(shop.ID) 
+	s.encodeShop(shop)
 
-	return shop, err
+	return shop, nil
 }
 
 func (s *shopServiceImpl) CreateShop(req *dto.ShopRequest) error {
-	// image should be uploaded here!
-	err := s.shopRepo.InsertShop(&domain.Shop{
-		Name:        req.Name,
-		Description: req.Description,
-	})
+	shop := &domain.Shop{
+		Name:         req.Name,
+		Description:  req.Description,
+		SlotCapacity: req.SlotCapacity,
+	}
 
-	return err
+	if req.Photo != nil {
+		key, err := uploadPhoto(s.storage, "shops", req.Photo)
+
+		if err != nil {
+			return err
+		}
+
+		shop.PhotoLink = key
+	}
+
+	err := s.shopRepo.InsertShop(shop)
+
+	if err != nil {
+		deletePhoto(s.storage, shop.PhotoLink)
+		return err
+	}
+
+	invalidateCatalogue(s.catalogue)
+
+	return nil
 }
 
 func (s *shopServiceImpl) AddOwner(req *dto.ShopParams) error {
@@ -99,12 +150,55 @@ func (s *shopServiceImpl) UpdateShop(params *dto.ShopParams, req *dto.ShopReques
 		return domain.ErrBadRequest
 	}
 
-	err = s.shopRepo.UpdateShop(params, &domain.Shop{
-		Name:        req.Name,
-		Description: req.Description,
-	})
+	if err := authorizeShop(s.shopRepo, params.ID, params.ActorID, params.ActorRole); err != nil {
+		return err
+	}
 
-	return err
+	current, err := s.shopRepo.FetchShopByID(params)
+
+	if err != nil {
+		return err
+	}
+
+	if err := checkVersion(current.Version, params.Versions); err != nil {
+		return err
+	}
+
+	// keep the current photo unless a new one is uploaded
+	shop := &domain.Shop{
+		Name:         req.Name,
+		Description:  req.Description,
+		SlotCapacity: req.SlotCapacity,
+		PhotoLink:    current.PhotoLink,
+	}
+
+	if req.Photo != nil {
+		key, err := uploadPhoto(s.storage, "shops", req.Photo)
+
+		if err != nil {
+			return err
+		}
+
+		shop.PhotoLink = key
+	}
+
+	err = s.shopRepo.UpdateShop(params, shop)
+
+	if err != nil {
+		if shop.PhotoLink != current.PhotoLink {
+			deletePhoto(s.storage, shop.PhotoLink)
+		}
+
+		return versionConflict(err, params.Versions)
+	}
+
+	if shop.PhotoLink != current.PhotoLink {
+		deletePhoto(s.storage, current.PhotoLink)
+	}
+
+	invalidateCatalogue(s.catalogue)
+
+	return nil
 }
 
 func (s *shopServiceImpl) DeleteShop(params *dto.ShopParams) error {
@@ -120,7 +214,30 @@ func (s *shopServiceImpl) DeleteShop(params *dto.ShopParams) error {
 		return domain.ErrBadRequest
 	}
 
+	shop, err := s.shopRepo.FetchShopByID(params)
+
+	if err != nil {
+		return err
+	}
+
 	err = s.shopRepo.DeleteShop(params)
 
-	return err
+	if err != nil {
+		return err
+	}
+
+	deletePhoto(s.storage, shop.PhotoLink)
+	invalidateCatalogue(s.catalogue)
+
+	return nil
+}
+
+func (s *shopServiceImpl) encodeShop(shop *domain.Shop) {
+	shop.ID = enc.Encode(shop.ID)
+
+	shop.Photos = photoVariants(s.storage, shop.PhotoLink)
+
+	if shop.PhotoLink != "" {
+		shop.PhotoLink = shop.Photos.Original
+	}
 }
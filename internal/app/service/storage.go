@@ -0,0 +1,119 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	"strings"
+
+	"github.com/devanfer02/filkom-canteen/domain"
+	"github.com/devanfer02/filkom-canteen/internal/pkg/imaging"
+	"github.com/devanfer02/filkom-canteen/internal/pkg/log"
+	"github.com/devanfer02/filkom-canteen/internal/pkg/storage"
+)
+
+// uploadImage validates the uploaded image and stores it under prefix with a
+// server generated name, returning the object key to persist.
+func uploadImage(store storage.StorageInterface, prefix string, fh *multipart.FileHeader) (string, error) {
+	image, err := storage.OpenImage(fh)
+
+	if err != nil {
+		return "", err
+	}
+
+	defer image.File.Close()
+
+	key := storage.NewKey(prefix, image.Extension)
+
+	if err = store.Put(context.Background(), key, image.File, image.Size, image.ContentType); err != nil {
+		return "", err
+	}
+
+	return key, nil
+}
+
+// uploadPhoto stores a cleaned copy of a shop or menu photo together with its
+// resized variants, returning the key of the original.
+func uploadPhoto(store storage.StorageInterface, prefix string, fh *multipart.FileHeader) (string, error) {
+	image, err := storage.OpenImage(fh)
+
+	if err != nil {
+		return "", err
+	}
+
+	defer image.File.Close()
+
+	key := storage.NewKey(prefix, image.Extension)
+	outputs, err := imaging.Process(key, image.File)
+
+	if err != nil {
+		return "", err
+	}
+
+	for idx, out := range outputs {
+		err = store.Put(context.Background(), out.Key, bytes.NewReader(out.Data), int64(len(out.Data)), out.ContentType)
+
+		if err != nil {
+			for _, stored := range outputs[:idx] {
+				deleteStoredFile(store, stored.Key)
+			}
+
+			return "", err
+		}
+	}
+
+	return key, nil
+}
+
+// deletePhoto removes a photo stored by uploadPhoto and all of its variants.
+func deletePhoto(store storage.StorageInterface, key string) {
+	if key == "" || !isStoredKey(key) {
+		return
+	}
+
+	deleteStoredFile(store, key)
+
+	for _, variant := range imaging.VariantKeys(key) {
+		deleteStoredFile(store, variant)
+	}
+}
+
+func photoVariants(store storage.StorageInterface, key string) *domain.ImageVariants {
+	if key == "" {
+		return nil
+	}
+
+	photos := &domain.ImageVariants{Original: store.URL(key)}
+
+	if !isStoredKey(key) {
+		return photos
+	}
+
+	photos.Thumbnail = store.URL(imaging.VariantKey(key, imaging.VariantThumbnail, ".jpg"))
+	photos.ThumbnailWebP = store.URL(imaging.VariantKey(key, imaging.VariantThumbnail, ".webp"))
+	photos.Medium = store.URL(imaging.VariantKey(key, imaging.VariantMedium, ".jpg"))
+	photos.MediumWebP = store.URL(imaging.VariantKey(key, imaging.VariantMedium, ".webp"))
+
+	return photos
+}
+
+// deleteStoredFile removes a stored object on a best effort basis, a leftover
+// file must never fail the request that replaced or removed it.
+func deleteStoredFile(store storage.StorageInterface, key string) {
+	if key == "" || !isStoredKey(key) {
+		return
+	}
+
+	if err := store.Delete(context.Background(), key); err != nil {
+		log.Warn(log.LogInfo{
+			"error": err.Error(),
+			"key":   key,
+		}, "[SERVICE][deleteStoredFile] failed to delete stored file")
+	}
+}
+
+// isStoredKey tells keys written by the storage apart from external links
+// saved before uploads were supported.
+func isStoredKey(key string) bool {
+	return !strings.Contains(key, "://")
+}
@@ -0,0 +1,28 @@
+package service
+
+import (
+	"slices"
+
+	"github.com/devanfer02/filkom-canteen/domain"
+)
+
+// checkVersion fails unless the current version of an item is one the update
+// was made against, no versions means any.
+func checkVersion(current int64, versions []int64) error {
+	if len(versions) == 0 || slices.Contains(versions, current) {
+		return nil
+	}
+
+	return domain.ErrPreconditionFailed
+}
+
+// versionConflict tells an update guarded by version that changed nothing
+// apart from a missing item, the item was fetched just before so it has been
+// changed in between.
+func versionConflict(err error, versions []int64) error {
+	if err == domain.ErrNotFound && len(versions) > 0 {
+		return domain.ErrPreconditionFailed
+	}
+
+	return err
+}
@@ -0,0 +1,14 @@
+package dto
+
+type MenuCategoryParams struct {
+	ID     string
+	ShopID string
+
+	ActorID   string
+	ActorRole string
+}
+
+type MenuCategoryRequest struct {
+	Name         string `json:"category_name" binding:"required,max=100"`
+	DisplayOrder int    `json:"display_order" binding:"min=0"`
+}
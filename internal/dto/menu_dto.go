@@ -2,16 +2,65 @@ package dto
 
 import "mime/multipart"
 
+const (
+	MenuSortNewest    = "newest"
+	MenuSortPriceAsc  = "price_asc"
+	MenuSortPriceDesc = "price_desc"
+	MenuSortNameAsc   = "name_asc"
+	MenuSortNameDesc  = "name_desc"
+	MenuSortPopular   = "popular"
+)
+
 type MenuParams struct {
-	ID     string
-	ShopID string
+	ID       string
+	ShopID   string
+	Page     PageParams
+	Filter   MenuFilter
+	Versions []int64
+
+	ActorID   string
+	ActorRole string
+}
+
+type MenuFilter struct {
+	MinPrice *int64 `form:"min_price" binding:"omitempty,min=0"`
+	MaxPrice *int64 `form:"max_price" binding:"omitempty,min=0"`
+	Status   string `form:"status" binding:"omitempty,oneof=available sold_out"`
+	Search   string `form:"q" binding:"max=100"`
+	Sort     string `form:"sort" binding:"omitempty,oneof=newest price_asc price_desc name_asc name_desc popular"`
 }
 
 type MenuRequest struct {
-	Name      string `json:"menu_name" db:"menu_name" binding:"required"`
-	ShopID    string `json:"shop_id" db:"shop_id" binding:"required"`
-	Price     int64  `json:"menu_price" db:"menu_price" binding:"required"`
-	Status    string `json:"menu_status" db:"menu_status" binding:"required"`
-	PhotoLink string `json:"menu_photo_link" db:"menu_photo_link"`
-	Photo     *multipart.FileHeader
+	Name   string `json:"menu_name" form:"menu_name" db:"menu_name" binding:"required"`
+	ShopID string `json:"shop_id" form:"shop_id" db:"shop_id" binding:"required"`
+	Price  int64  `json:"menu_price" form:"menu_price" db:"menu_price" binding:"required"`
+	Status string `json:"menu_status" form:"menu_status" db:"menu_status" binding:"required"`
+	// DailyStock turns on stock tracking, leaving it out turns it off. Stock
+	// overrides what is left for today and defaults to the daily stock.
+	DailyStock *int64 `json:"daily_stock" form:"daily_stock" binding:"omitempty,min=0"`
+	Stock      *int64 `json:"stock_remaining" form:"stock_remaining" binding:"omitempty,min=0"`
+	// CategoryID is optional, an empty value removes the menu from its category
+	CategoryID string                `json:"category_id" form:"category_id"`
+	Photo      *multipart.FileHeader `json:"-" form:"photo" swaggerignore:"true"`
+}
+
+// MenuOptionsRequest replaces every option group of a menu, an empty list
+// removes all options.
+type MenuOptionsRequest struct {
+	Groups []MenuOptionGroupRequest `json:"groups" binding:"max=20,dive"`
+}
+
+type MenuOptionGroupRequest struct {
+	Name      string                   `json:"group_name" binding:"required,max=100"`
+	MinSelect int                      `json:"min_select" binding:"min=0"`
+	MaxSelect int                      `json:"max_select" binding:"required,min=1"`
+	Required  bool                     `json:"is_required"`
+	Values    []MenuOptionValueRequest `json:"values" binding:"required,min=1,max=30,dive"`
+}
+
+type MenuOptionValueRequest struct {
+	Name       string `json:"value_name" binding:"required,max=100"`
+	PriceDelta int64  `json:"price_delta"`
+	// Available defaults to true when left out
+	Available *bool `json:"is_available"`
 }
@@ -1,22 +1,80 @@
 package dto
 
-import "mime/multipart"
+import (
+	"mime/multipart"
+	"time"
+)
 
 type OrderParams struct {
-	ID     string
-	UserID string
-	MenuID string
-	ShopID string
+	ID      string
+	UserID  string
+	MenuID  string
+	ShopID  string
+	OwnerID string
+	Status  string
+
+	PaymentStatus string
+	Page          PageParams
+	Versions      []int64
+
+	PickupFrom time.Time
+	PickupTo   time.Time
+	QueueDate  string
+
+	ActorID   string
+	ActorType string
+	ActorRole string
 }
 
 type OrderRequest struct {
-	ID               string                `json:"order_id"`
-	UserID           string                `json:"user_id"`
-	MenuID           string                `json:"menu_id" binding:"required"`
-	Status           string                `json:"status"`
-	PaymentMethod    string                `json:"payment_method" binding:"required"`
-	PaymentProofLink string                `json:"payment_proof_link"`
-	PaymentProofFile *multipart.FileHeader `form:"payment_proof"`
-	CreatedAt        string                `json:"created_at"`
-	UpdatedAt        string                `json:"updated_at"`
+	Items         []OrderItemRequest `json:"items" binding:"required,min=1,dive"`
+	PaymentMethod string             `json:"payment_method" binding:"required,oneof=COD QRIS"`
+	// PickupAt requests a pickup slot, it must be the start of a slot
+	PickupAt *time.Time `json:"pickup_at"`
+}
+
+type OrderItemRequest struct {
+	MenuID   string `json:"menu_id" binding:"required"`
+	Quantity int64  `json:"quantity" binding:"required,min=1,max=100"`
+	Notes    string `json:"notes"`
+	// Options holds the ids of the chosen option values
+	Options []string `json:"options" binding:"max=50"`
+}
+
+type OrderUpdateRequest struct {
+	Status        string `json:"status" binding:"required"`
+	PaymentMethod string `json:"payment_method" binding:"omitempty,oneof=COD QRIS"`
+	Reason        string `json:"reason"`
+}
+
+// OrderCancelRequest holds the cancellation reason, owners and admins must
+// always give one while students may leave it empty.
+type OrderCancelRequest struct {
+	Reason string `json:"reason" binding:"max=255"`
+}
+
+// KitchenCommand is a message sent by a shop tablet over the kitchen channel,
+// RequestID is echoed back so the tablet can match the reply. Version plays
+// the part of If-Match on the http route.
+type KitchenCommand struct {
+	RequestID string `json:"request_id"`
+	Action    string `json:"action" binding:"required,oneof=update_status"`
+	OrderID   string `json:"order_id" binding:"required"`
+	Version   int64  `json:"version" binding:"required,min=1"`
+	OrderUpdateRequest
+}
+
+// KitchenTicket lets a browser open the kitchen channel, it is sent as the
+// ticket query and works once before ExpiresAt.
+type KitchenTicket struct {
+	Ticket    string    `json:"ticket"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type PaymentProofRequest struct {
+	PaymentProofFile *multipart.FileHeader `form:"payment_proof" binding:"required"`
+}
+
+type PaymentVerificationRequest struct {
+	Reason string `json:"reason"`
 }
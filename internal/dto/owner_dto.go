@@ -1,7 +1,12 @@
 package dto
 
 type OwnerParams struct {
-	ID string
+	ID       string
+	Page     PageParams
+	Versions []int64
+
+	ActorID   string
+	ActorRole string
 }
 
 type OwnerRequest struct {
@@ -0,0 +1,22 @@
+package dto
+
+const (
+	DefaultPageLimit = 20
+	MaxPageLimit     = 100
+)
+
+type PageParams struct {
+	Limit  int
+	Cursor string
+
+	// AfterID and AfterValue are the decoded cursor, set by the service
+	// layer. AfterValue is only used when listing by a column other than id.
+	AfterID    string
+	AfterValue string
+}
+
+type Pagination struct {
+	Limit      int    `json:"limit"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	HasMore    bool   `json:"has_more"`
+}
@@ -0,0 +1,11 @@
+package dto
+
+const (
+	DefaultSearchLimit = 10
+	MaxSearchLimit     = 30
+)
+
+type SearchParams struct {
+	Query string `form:"q" binding:"required,min=2,max=100"`
+	Limit int    `form:"limit" binding:"omitempty,min=1"`
+}
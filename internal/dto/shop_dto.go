@@ -3,13 +3,18 @@ package dto
 import "mime/multipart"
 
 type ShopParams struct {
-	ID      string
-	OwnerID string
+	ID       string
+	OwnerID  string
+	Page     PageParams
+	Versions []int64
+
+	ActorID   string
+	ActorRole string
 }
 
 type ShopRequest struct {
-	Name        string `json:"shop_name" binding:"required"`
-	Description string `json:"shop_description" binding:"required"`
-	Photo       *multipart.FileHeader
-	PhotoLink   string `json:"photo_link"`
+	Name         string                `json:"shop_name" form:"shop_name" binding:"required"`
+	Description  string                `json:"shop_description" form:"shop_description" binding:"required"`
+	SlotCapacity *int                  `json:"pickup_slot_capacity" form:"pickup_slot_capacity" binding:"omitempty,min=1"`
+	Photo        *multipart.FileHeader `json:"-" form:"photo" swaggerignore:"true"`
 }
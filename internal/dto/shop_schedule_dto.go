@@ -0,0 +1,32 @@
+package dto
+
+type ShopScheduleParams struct {
+	ShopID    string
+	ClosureID string
+	Versions  []int64
+
+	ActorID   string
+	ActorRole string
+}
+
+// ShopHoursRequest replaces the whole weekly schedule of a shop, an empty
+// list makes the shop open around the clock.
+type ShopHoursRequest struct {
+	Hours []ShopHoursItem `json:"hours" binding:"max=50,dive"`
+}
+
+type ShopHoursItem struct {
+	Weekday   *int   `json:"weekday" binding:"required,min=0,max=6"`
+	OpenTime  string `json:"open_time" binding:"required,datetime=15:04"`
+	CloseTime string `json:"close_time" binding:"required,datetime=15:04"`
+}
+
+type ShopClosureRequest struct {
+	Date   string `json:"date" binding:"required,datetime=2006-01-02"`
+	Reason string `json:"reason" binding:"max=255"`
+}
+
+type ShopStatusRequest struct {
+	Closed *bool  `json:"closed" binding:"required"`
+	Reason string `json:"reason" binding:"max=255"`
+}
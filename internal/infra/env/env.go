@@ -9,6 +9,7 @@ type Env struct {
 	AppEnv        string `mapstructure:"APP_ENV"`
 	AppPort       string `mapstructure:"APP_PORT"`
 	AppUrl        string `mapstructure:"APP_URL"`
+	AppTimezone   string `mapstructure:"APP_TIMEZONE"`
 	DBHost        string `mapstructure:"DB_HOST"`
 	DBPort        string `mapstructure:"DB_PORT"`
 	DBUser        string `mapstructure:"DB_USER"`
@@ -21,6 +22,24 @@ type Env struct {
 	RedisPort     string `mapstructure:"REDIS_PORT"`
 	RedisPassword string `mapstructure:"REDIS_PASS"`
 	ApiKey        string `mapstructure:"API_KEY"`
+	StorageDriver string `mapstructure:"STORAGE_DRIVER"`
+	StoragePath   string `mapstructure:"STORAGE_LOCAL_PATH"`
+	StorageKey    string `mapstructure:"STORAGE_SIGNING_KEY"`
+	StorageMaxMB  int64  `mapstructure:"STORAGE_MAX_UPLOAD_MB"`
+	S3Endpoint    string `mapstructure:"S3_ENDPOINT"`
+	S3Region      string `mapstructure:"S3_REGION"`
+	S3Bucket      string `mapstructure:"S3_BUCKET"`
+	S3AccessKey   string `mapstructure:"S3_ACCESS_KEY"`
+	S3SecretKey   string `mapstructure:"S3_SECRET_KEY"`
+	S3UseSSL      bool   `mapstructure:"S3_USE_SSL"`
+	S3PublicURL   string `mapstructure:"S3_PUBLIC_URL"`
+	StockResetAt  string `mapstructure:"STOCK_RESET_TIME"`
+	SlotMinutes   int    `mapstructure:"ORDER_SLOT_MINUTES"`
+	PreorderDays  int    `mapstructure:"ORDER_PREORDER_DAYS"`
+	IdempotentTTL int    `mapstructure:"IDEMPOTENCY_TTL_HOURS"`
+	RateLimits    string `mapstructure:"RATE_LIMITS"`
+	CacheTTL      int    `mapstructure:"RESPONSE_CACHE_SECONDS"`
+	WSOrigins     string `mapstructure:"WS_ALLOWED_ORIGINS"`
 }
 
 var AppEnv = getEnv()
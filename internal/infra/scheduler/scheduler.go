@@ -0,0 +1,50 @@
+package scheduler
+
+import (
+	"time"
+
+	"github.com/devanfer02/filkom-canteen/internal/pkg/clock"
+	"github.com/devanfer02/filkom-canteen/internal/pkg/log"
+)
+
+// Daily runs job every day at the given HH:MM in the canteen timezone. A job
+// is also run right away when the server starts after today's run time, so a
+// restart never skips a day. Jobs must therefore be safe to repeat, which
+// also keeps several API instances from doing the work twice.
+func Daily(name, at string, job func() error) error {
+	minutes, err := clock.ParseTimeOfDay(at)
+
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		now := clock.Now()
+		year, month, day := now.Date()
+
+		if !now.Before(time.Date(year, month, day, minutes/60, minutes%60, 0, 0, now.Location())) {
+			run(name, job)
+		}
+
+		for {
+			time.Sleep(time.Until(clock.Next(clock.Now(), minutes)))
+			run(name, job)
+		}
+	}()
+
+	return nil
+}
+
+func run(name string, job func() error) {
+	if err := job(); err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+			"job":   name,
+		}, "[SCHEDULER][run] scheduled job failed")
+		return
+	}
+
+	log.Info(log.LogInfo{
+		"job": name,
+	}, "[SCHEDULER][run] scheduled job finished")
+}
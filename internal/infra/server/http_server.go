@@ -1,6 +1,8 @@
 package server
 
 import (
+	"time"
+
 	"github.com/gin-gonic/gin"
 	"github.com/jmoiron/sqlx"
 	swaggerFiles "github.com/swaggo/files"
@@ -10,9 +12,20 @@ import (
 	"github.com/devanfer02/filkom-canteen/internal/app/repository"
 	"github.com/devanfer02/filkom-canteen/internal/app/service"
 	"github.com/devanfer02/filkom-canteen/internal/infra/env"
+	"github.com/devanfer02/filkom-canteen/internal/infra/scheduler"
 	"github.com/devanfer02/filkom-canteen/internal/middleware"
+	"github.com/devanfer02/filkom-canteen/internal/pkg/cache"
+	"github.com/devanfer02/filkom-canteen/internal/pkg/events"
+	"github.com/devanfer02/filkom-canteen/internal/pkg/flag"
 	"github.com/devanfer02/filkom-canteen/internal/pkg/log"
 	"github.com/devanfer02/filkom-canteen/internal/pkg/redis"
+	"github.com/devanfer02/filkom-canteen/internal/pkg/storage"
+	"github.com/devanfer02/filkom-canteen/internal/pkg/ticket"
+)
+
+const (
+	defaultStockResetAt = "04:00"
+	defaultCacheTTL     = time.Minute
 )
 
 type Server interface {
@@ -37,14 +50,29 @@ func NewHTTPServer(dbx *sqlx.DB) Server {
 
 func (h *httpServer) MountMiddlewares() {
 	h.app.Use(middleware.CORS())
-	
+
 }
 
 func (h *httpServer) MountControllers() {
 	v1 := h.app.Group("/api/v1")
 	v1.Use(middleware.APIKey())
 
+	// browser WebSockets cannot send the api key header, routes here
+	// authenticate with a ticket issued through an api keyed route instead
+	ws := h.app.Group("/api/v1")
+
 	redis := redis.NewRedisClient()
+	store := storage.NewStorage()
+	broker := events.NewBroker(redis)
+
+	cacheTTL := defaultCacheTTL
+
+	if env.AppEnv.CacheTTL > 0 {
+		cacheTTL = time.Duration(env.AppEnv.CacheTTL) * time.Second
+	}
+
+	responses := cache.NewResponseCache(redis, cacheTTL)
+	tickets := ticket.NewTicketStore(redis)
 
 	url := ginSwagger.URL(env.AppEnv.AppUrl + `/swagger/doc.json`)
 
@@ -52,26 +80,64 @@ func (h *httpServer) MountControllers() {
 	shopRepo := repository.NewShowRepository(h.dbx)
 	ownerRepo := repository.NewOwnerRepository(h.dbx)
 	menuRepo := repository.NewMenuRepository(h.dbx)
-	roleRepo := repository.NewRoleRepository(h.dbx)
+	roleRepo := repository.NewRoleCache(repository.NewRoleRepository(h.dbx), redis, broker)
 	orderRepo := repository.NewOrderRepository(h.dbx)
+	searchRepo := repository.NewSearchRepository(h.dbx)
+	categoryRepo := repository.NewMenuCategoryRepository(h.dbx)
+	optionRepo := repository.NewMenuOptionRepository(h.dbx)
+	scheduleRepo := repository.NewShopScheduleRepository(h.dbx)
+
+	// seeding rewrites the roles table, so lookups cached before are stale
+	if flag.Flags.Fresh || flag.Flags.Seeder {
+		if err := roleRepo.Invalidate(); err != nil {
+			log.Warn(log.LogInfo{
+				"error": err.Error(),
+			}, "[HTTP SERVER][MountControllers] failed to invalidate role cache")
+		}
+	}
 
 	// middlewares
-	mdlwr := middleware.NewMiddleware(redis, roleRepo)
+	mdlwr := middleware.NewMiddleware(redis, roleRepo, responses, tickets)
 
 	// services
-	shopSvc := service.NewShopService(shopRepo)
+	shopSvc := service.NewShopService(shopRepo, scheduleRepo, store, responses)
 	ownerSvc := service.NewOwnerService(ownerRepo)
-	menuSvc := service.NewMenuService(menuRepo)
-	orderSvc := service.NewOrderService(orderRepo)
+	menuSvc := service.NewMenuService(menuRepo, shopRepo, categoryRepo, optionRepo, store, responses)
+	orderSvc := service.NewOrderService(orderRepo, menuRepo, optionRepo, shopRepo, scheduleRepo, store, broker, responses)
+	searchSvc := service.NewSearchService(searchRepo, scheduleRepo, store)
+	categorySvc := service.NewMenuCategoryService(categoryRepo, shopRepo, responses)
+	scheduleSvc := service.NewShopScheduleService(scheduleRepo, shopRepo, responses)
+
+	// jobs
+	resetAt := env.AppEnv.StockResetAt
+
+	if resetAt == "" {
+		resetAt = defaultStockResetAt
+	}
+
+	if err := scheduler.Daily("reset daily stock", resetAt, menuSvc.ResetDailyStock); err != nil {
+		log.Fatal(log.LogInfo{
+			"error": err.Error(),
+		}, "[HTTP SERVER][MountControllers] failed to schedule daily stock reset")
+	}
 
 	// controllers
 	controller.MountShopRoutes(v1, shopSvc, mdlwr)
+	controller.MountShopScheduleRoutes(v1, scheduleSvc, mdlwr)
 	controller.MountOwnerRoutes(v1, ownerSvc, mdlwr)
 	controller.MountMenuRoutes(v1, menuSvc, mdlwr)
+	controller.MountMenuCategoryRoutes(v1, categorySvc, mdlwr)
 	controller.MountOrderRoutes(v1, orderSvc, mdlwr)
+	controller.MountKitchenRoutes(v1, ws, orderSvc, tickets, mdlwr)
+	controller.MountBoardRoutes(v1, orderSvc)
+	controller.MountSearchRoutes(v1, searchSvc, mdlwr)
 
 	h.app.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler, url))
 
+	if storage.IsLocal() {
+		controller.MountFileRoutes(&h.app.RouterGroup, store)
+	}
+
 	h.app.GET("/hello", mdlwr.Authenticate(), mdlwr.AuthorizeAdmin("Owner"), func(ctx *gin.Context) {
 		ctx.String(200, "Hello world")
 	})
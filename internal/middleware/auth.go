@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"context"
 	"errors"
 	"slices"
 	"strings"
@@ -58,10 +59,9 @@ func (m *Middleware) Authenticate() gin.HandlerFunc {
 
 		if _, err = uuid.Parse(issuer.UserID); err != nil {
 			err = errors.New("failed to authenticate")
-			return 
+			return
 		}
 
-
 		ctx.Set("id", issuer.UserID)
 		ctx.Set("user", issuer.Issuer)
 		ctx.Set("role", issuer.Role)
@@ -69,6 +69,25 @@ func (m *Middleware) Authenticate() gin.HandlerFunc {
 	}
 }
 
+// Ticket authenticates with a one time ticket from the ticket query instead of
+// the Authorization header, for browser WebSockets which cannot set headers.
+func (m *Middleware) Ticket() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		issued, err := m.tickets.Redeem(context.Background(), ctx.Query("ticket"))
+
+		if err != nil {
+			code, status := domain.GetStatus(domain.ErrInvalidToken)
+			ginlib.SendAbortResponse(ctx, code, status, "failed to authenticate user", domain.ErrInvalidToken)
+			return
+		}
+
+		ctx.Set("id", issued.UserID)
+		ctx.Set("user", issued.Issuer)
+		ctx.Set("role", issued.Role)
+		ctx.Next()
+	}
+}
+
 func (m *Middleware) AuthorizeAdmin(roles ...string) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		var (
@@ -85,16 +104,7 @@ func (m *Middleware) AuthorizeAdmin(roles ...string) gin.HandlerFunc {
 			}
 		}()
 
-		if _, err = uuid.Parse(ctx.GetString("role")); err != nil {
-			err = errors.New("failed to authorize")
-			return 
-		}
-
-		role, err = m.roleRepo.FetchOne(ctx.GetString("role"))
-
-
-		if err != nil {
-			err = errors.New("failed to authorize")
+		if role, err = m.resolveRole(ctx); err != nil {
 			return
 		}
 
@@ -110,3 +120,50 @@ func (m *Middleware) AuthorizeAdmin(roles ...string) gin.HandlerFunc {
 		ctx.Next()
 	}
 }
+
+func (m *Middleware) ResolveRole() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var (
+			err     error
+			code    = 401
+			status  = "fail"
+			message = "unauthorized"
+		)
+
+		defer func() {
+			if err != nil {
+				ginlib.SendAbortResponse(ctx, code, status, message, err)
+			}
+		}()
+
+		// only admin tokens carry a role, student tokens pass through untouched
+		if ctx.GetString("user") != env.AppEnv.JWTAdminRole {
+			ctx.Next()
+			return
+		}
+
+		if _, err = m.resolveRole(ctx); err != nil {
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+// resolveRole looks up the role of an admin token and stores its name as
+// role_name for the handlers.
+func (m *Middleware) resolveRole(ctx *gin.Context) (*domain.Role, error) {
+	if _, err := uuid.Parse(ctx.GetString("role")); err != nil {
+		return nil, errors.New("failed to authorize")
+	}
+
+	role, err := m.roleRepo.FetchOne(ctx.GetString("role"))
+
+	if err != nil {
+		return nil, errors.New("failed to authorize")
+	}
+
+	ctx.Set("role_name", role.Name)
+
+	return role, nil
+}
@@ -0,0 +1,236 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/devanfer02/filkom-canteen/domain"
+	"github.com/devanfer02/filkom-canteen/internal/infra/env"
+	ginlib "github.com/devanfer02/filkom-canteen/internal/pkg/gin"
+	"github.com/devanfer02/filkom-canteen/internal/pkg/log"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	idempotencyHeader      = "Idempotency-Key"
+	idempotencyMaxKey      = 255
+	idempotencyDefaultTTL  = 24 * time.Hour
+	idempotencyLockTTL     = time.Minute
+	idempotencyMultipartMB = 32
+)
+
+type idempotencyRecord struct {
+	Fingerprint string `json:"fingerprint"`
+	Done        bool   `json:"done"`
+	Code        int    `json:"code,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+	Body        []byte `json:"body,omitempty"`
+}
+
+// responseRecorder keeps a copy of everything the handler writes so it can
+// be replayed later.
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *responseRecorder) WriteString(data string) (int, error) {
+	w.body.WriteString(data)
+	return w.ResponseWriter.WriteString(data)
+}
+
+// Idempotency replays the stored response of a request sent again with the
+// same Idempotency-Key header, keys are scoped per user and must come after
+// Authenticate. Requests without the header pass through, and so does
+// everything while redis is unreachable.
+func (m *Middleware) Idempotency() gin.HandlerFunc {
+	ttl := idempotencyDefaultTTL
+
+	if env.AppEnv.IdempotentTTL > 0 {
+		ttl = time.Duration(env.AppEnv.IdempotentTTL) * time.Hour
+	}
+
+	return func(ctx *gin.Context) {
+		var (
+			err      error
+			code     = 400
+			status   = "fail"
+			message  = "failed to process idempotent request"
+			record   idempotencyRecord
+			acquired bool
+		)
+
+		defer func() {
+			if err != nil {
+				ginlib.SendAbortResponse(ctx, code, status, message, err)
+			}
+		}()
+
+		idemKey := strings.TrimSpace(ctx.GetHeader(idempotencyHeader))
+
+		if idemKey == "" {
+			ctx.Next()
+			return
+		}
+
+		if len(idemKey) > idempotencyMaxKey {
+			err = domain.ErrBadRequest
+			return
+		}
+
+		fingerprint, err := requestFingerprint(ctx)
+
+		if err != nil {
+			err = domain.ErrBadRequest
+			return
+		}
+
+		key := "idempotency:" + ctx.GetString("id") + ":" + idemKey
+		pending, _ := json.Marshal(idempotencyRecord{Fingerprint: fingerprint})
+
+		acquired, err = m.redis.SetNX(context.Background(), key, pending, idempotencyLockTTL)
+
+		if err != nil {
+			err = nil
+			ctx.Next()
+			return
+		}
+
+		if !acquired {
+			var stored string
+
+			stored, err = m.redis.Get(context.Background(), key)
+
+			if err != nil || stored == "" || json.Unmarshal([]byte(stored), &record) != nil {
+				err = nil
+				ctx.Next()
+				return
+			}
+
+			switch {
+			case record.Fingerprint != fingerprint:
+				err = domain.ErrIdempotencyKeyReused
+			case !record.Done:
+				err = domain.ErrIdempotencyInProgress
+			default:
+				ctx.Header("Idempotent-Replayed", "true")
+				ctx.Data(record.Code, record.ContentType, record.Body)
+				ctx.Abort()
+				return
+			}
+
+			code, status = domain.GetStatus(err)
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: ctx.Writer}
+		ctx.Writer = recorder
+
+		ctx.Next()
+
+		if isRetryable(recorder.Status()) {
+			m.redis.Delete(context.Background(), key)
+			return
+		}
+
+		done, _ := json.Marshal(idempotencyRecord{
+			Fingerprint: fingerprint,
+			Done:        true,
+			Code:        recorder.Status(),
+			ContentType: recorder.Header().Get("Content-Type"),
+			Body:        recorder.body.Bytes(),
+		})
+
+		if setErr := m.redis.Set(context.Background(), key, done, ttl); setErr != nil {
+			log.Warn(log.LogInfo{
+				"error": setErr.Error(),
+			}, "[MIDDLEWARE][Idempotency] failed to store response")
+		}
+	}
+}
+
+// isRetryable tells responses that may turn out differently when sent again,
+// such as a full slot or a shop that was closed, which must not be replayed.
+// Server errors are not final either.
+func isRetryable(code int) bool {
+	return code == 409 || code == 429 || code >= 500
+}
+
+// requestFingerprint hashes what makes two requests the same. Multipart
+// bodies are hashed by their fields and files rather than their raw bytes,
+// since clients pick a new boundary on every retry.
+func requestFingerprint(ctx *gin.Context) (string, error) {
+	hash := sha256.New()
+
+	io.WriteString(hash, ctx.Request.Method+" "+ctx.Request.URL.Path+"\n")
+
+	if strings.HasPrefix(ctx.ContentType(), "multipart/") {
+		if err := ctx.Request.ParseMultipartForm(idempotencyMultipartMB << 20); err != nil {
+			return "", err
+		}
+
+		form := ctx.Request.MultipartForm
+
+		for _, name := range sortedKeys(form.Value) {
+			for _, value := range form.Value[name] {
+				io.WriteString(hash, name+"="+value+"\n")
+			}
+		}
+
+		for _, name := range sortedKeys(form.File) {
+			for _, header := range form.File[name] {
+				file, err := header.Open()
+
+				if err != nil {
+					return "", err
+				}
+
+				io.WriteString(hash, name+"="+header.Filename+"\n")
+				_, err = io.Copy(hash, file)
+				file.Close()
+
+				if err != nil {
+					return "", err
+				}
+			}
+		}
+
+		return hex.EncodeToString(hash.Sum(nil)), nil
+	}
+
+	body, err := io.ReadAll(ctx.Request.Body)
+
+	if err != nil {
+		return "", err
+	}
+
+	// hand the body back to the handler
+	ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+	hash.Write(body)
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func sortedKeys[T any](values map[string]T) []string {
+	keys := make([]string, 0, len(values))
+
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -1,25 +1,141 @@
 package middleware
 
 import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/devanfer02/filkom-canteen/domain"
+	"github.com/devanfer02/filkom-canteen/internal/infra/env"
+	ginlib "github.com/devanfer02/filkom-canteen/internal/pkg/gin"
+	"github.com/devanfer02/filkom-canteen/internal/pkg/log"
 	"github.com/gin-gonic/gin"
-	"github.com/ulule/limiter/v3"
-	middleware "github.com/ulule/limiter/v3/drivers/middleware/gin"
-	"github.com/ulule/limiter/v3/drivers/store/memory"
 )
 
-func (m *Middleware) RateLimiter(limit int64) gin.HandlerFunc {
-	var (
-		rate = limiter.Rate{
-			Period: 1 * time.Hour,
-			Limit: limit,
+type RatePolicy struct {
+	Limit  int64
+	Window time.Duration
+}
+
+// defaultRatePolicies apply unless RATE_LIMITS overrides them.
+var defaultRatePolicies = map[string]RatePolicy{
+	"order-create":  {Limit: 50, Window: time.Hour},
+	"payment-proof": {Limit: 20, Window: time.Hour},
+	"search":        {Limit: 60, Window: time.Minute},
+}
+
+var (
+	ratePolicies     map[string]RatePolicy
+	ratePoliciesOnce sync.Once
+)
+
+// RateLimiter counts requests per fixed window in redis, so limits hold
+// across restarts and instances. Authenticated callers are counted by user
+// id, everyone else by client ip. When redis is unreachable requests pass.
+func (m *Middleware) RateLimiter(name string) gin.HandlerFunc {
+	policy, ok := loadRatePolicies()[name]
+
+	if !ok {
+		log.Fatal(log.LogInfo{
+			"policy": name,
+		}, "[MIDDLEWARE][RateLimiter] unknown rate limit policy")
+	}
+
+	return func(ctx *gin.Context) {
+		subject := "user:" + ctx.GetString("id")
+
+		if ctx.GetString("id") == "" {
+			subject = "ip:" + ctx.ClientIP()
+		}
+
+		now := time.Now()
+		window := now.Truncate(policy.Window)
+		reset := int64(window.Add(policy.Window).Sub(now).Seconds()) + 1
+		key := fmt.Sprintf("ratelimit:%s:%s:%d", name, subject, window.Unix())
+
+		count, err := m.redis.Incr(context.Background(), key, policy.Window)
+
+		if err != nil {
+			ctx.Next()
+			return
+		}
+
+		remaining := max(policy.Limit-count, 0)
+
+		ctx.Header("RateLimit-Policy", fmt.Sprintf("%d;w=%d", policy.Limit, int64(policy.Window.Seconds())))
+		ctx.Header("RateLimit-Limit", strconv.FormatInt(policy.Limit, 10))
+		ctx.Header("RateLimit-Remaining", strconv.FormatInt(remaining, 10))
+		ctx.Header("RateLimit-Reset", strconv.FormatInt(reset, 10))
+
+		if count > policy.Limit {
+			code, status := domain.GetStatus(domain.ErrTooManyRequests)
+
+			ctx.Header("Retry-After", strconv.FormatInt(reset, 10))
+			ginlib.SendAbortResponse(ctx, code, status, "rate limit exceeded", domain.ErrTooManyRequests)
+			return
 		}
 
-		store = memory.NewStore()
+		ctx.Next()
+	}
+}
+
+func loadRatePolicies() map[string]RatePolicy {
+	ratePoliciesOnce.Do(func() {
+		ratePolicies = make(map[string]RatePolicy, len(defaultRatePolicies))
+
+		for name, policy := range defaultRatePolicies {
+			ratePolicies[name] = policy
+		}
+
+		for _, entry := range strings.Split(env.AppEnv.RateLimits, ",") {
+			if strings.TrimSpace(entry) == "" {
+				continue
+			}
+
+			name, policy, err := parseRatePolicy(entry)
+
+			if err != nil {
+				log.Fatal(log.LogInfo{
+					"error": err.Error(),
+					"entry": entry,
+				}, "[MIDDLEWARE][loadRatePolicies] invalid rate limit policy")
+			}
+
+			ratePolicies[name] = policy
+		}
+	})
+
+	return ratePolicies
+}
+
+// parseRatePolicy reads a name=limit/window entry such as order-create=50/1h.
+func parseRatePolicy(entry string) (string, RatePolicy, error) {
+	name, rule, found := strings.Cut(strings.TrimSpace(entry), "=")
+
+	if !found || name == "" {
+		return "", RatePolicy{}, fmt.Errorf("expected name=limit/window")
+	}
+
+	limit, window, found := strings.Cut(rule, "/")
+
+	if !found {
+		return "", RatePolicy{}, fmt.Errorf("expected limit/window")
+	}
+
+	parsedLimit, err := strconv.ParseInt(limit, 10, 64)
+
+	if err != nil || parsedLimit < 1 {
+		return "", RatePolicy{}, fmt.Errorf("invalid limit %q", limit)
+	}
+
+	parsedWindow, err := time.ParseDuration(window)
 
-		instance = limiter.New(store, rate)
-	)
+	if err != nil || parsedWindow < time.Second {
+		return "", RatePolicy{}, fmt.Errorf("invalid window %q", window)
+	}
 
-	return middleware.NewMiddleware(instance)
+	return name, RatePolicy{Limit: parsedLimit, Window: parsedWindow}, nil
 }
@@ -0,0 +1,125 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"github.com/devanfer02/filkom-canteen/internal/pkg/cache"
+	"github.com/gin-gonic/gin"
+)
+
+// replayedHeaders are the response headers a cached response keeps.
+var replayedHeaders = []string{"Content-Type", "X-Cursor"}
+
+// bufferedWriter holds the whole response back, so headers that depend on
+// the body such as ETag can still be set once the handler is done.
+type bufferedWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *bufferedWriter) Write(data []byte) (int, error) {
+	return w.body.Write(data)
+}
+
+func (w *bufferedWriter) WriteString(data string) (int, error) {
+	return w.body.WriteString(data)
+}
+
+func (w *bufferedWriter) WriteHeaderNow() {}
+
+// CacheResponse serves public reads from the response cache and answers
+// conditional requests with 304 when the ETag still matches. Only 200
+// responses are cached, and only until the cache is invalidated or expires.
+func (m *Middleware) CacheResponse() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		key := responseCacheKey(ctx)
+
+		cached, version, hit := m.responses.Get(ctx, key)
+
+		if hit {
+			ctx.Header("X-Cache", "HIT")
+			writeResponse(ctx, cached)
+			ctx.Abort()
+			return
+		}
+
+		buffer := &bufferedWriter{ResponseWriter: ctx.Writer}
+		ctx.Writer = buffer
+
+		ctx.Next()
+
+		ctx.Writer = buffer.ResponseWriter
+
+		res := &cache.CachedResponse{
+			Code:    buffer.Status(),
+			Headers: make(map[string]string, len(replayedHeaders)),
+			Body:    buffer.body.Bytes(),
+		}
+
+		for _, name := range replayedHeaders {
+			if value := ctx.Writer.Header().Get(name); value != "" {
+				res.Headers[name] = value
+			}
+		}
+
+		if res.Code == 200 {
+			// single items carry their version, plus any computed state, as
+			// ETag so If-Match on updates works with the same tag
+			res.ETag = ctx.Writer.Header().Get("ETag")
+
+			if res.ETag == "" {
+				sum := sha256.Sum256(res.Body)
+				res.ETag = `"` + hex.EncodeToString(sum[:16]) + `"`
+			}
+
+			m.responses.Set(ctx, key, version, res)
+		}
+
+		ctx.Header("X-Cache", "MISS")
+		writeResponse(ctx, res)
+	}
+}
+
+func writeResponse(ctx *gin.Context, res *cache.CachedResponse) {
+	for name, value := range res.Headers {
+		ctx.Header(name, value)
+	}
+
+	if res.ETag != "" {
+		// clients may keep the body but have to revalidate it every time
+		ctx.Header("Cache-Control", "no-cache")
+		ctx.Header("ETag", res.ETag)
+
+		if etagMatches(ctx.GetHeader("If-None-Match"), res.ETag) {
+			ctx.Status(304)
+			ctx.Writer.WriteHeaderNow()
+			return
+		}
+	}
+
+	ctx.Status(res.Code)
+	ctx.Writer.Write(res.Body)
+}
+
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// responseCacheKey tells apart every url and page, the cursor may come in
+// the X-Cursor header instead of the query.
+func responseCacheKey(ctx *gin.Context) string {
+	sum := sha256.Sum256([]byte(ctx.Request.URL.RequestURI() + "|" + ctx.GetHeader("X-Cursor")))
+
+	return hex.EncodeToString(sum[:])
+}
@@ -2,14 +2,23 @@ package middleware
 
 import (
 	"github.com/devanfer02/filkom-canteen/internal/app/repository"
+	"github.com/devanfer02/filkom-canteen/internal/pkg/cache"
 	"github.com/devanfer02/filkom-canteen/internal/pkg/redis"
+	"github.com/devanfer02/filkom-canteen/internal/pkg/ticket"
 )
 
 type Middleware struct {
-	redis    redis.RedisInterface
-	roleRepo repository.IRoleRepository
+	redis     redis.RedisInterface
+	roleRepo  repository.IRoleRepository
+	responses cache.ResponseCacheInterface
+	tickets   ticket.TicketInterface
 }
 
-func NewMiddleware(redis redis.RedisInterface, roleRepo repository.IRoleRepository) *Middleware {
-	return &Middleware{redis: redis, roleRepo: roleRepo}
+func NewMiddleware(
+	redis redis.RedisInterface,
+	roleRepo repository.IRoleRepository,
+	responses cache.ResponseCacheInterface,
+	tickets ticket.TicketInterface,
+) *Middleware {
+	return &Middleware{redis: redis, roleRepo: roleRepo, responses: responses, tickets: tickets}
 }
@@ -0,0 +1,94 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/devanfer02/filkom-canteen/internal/pkg/redis"
+)
+
+const (
+	responseVersionKey = "responses:version"
+	// only has to outlive the cached responses, so a reset version can
+	// never bring back an old entry
+	responseVersionTTL = 30 * 24 * time.Hour
+)
+
+type CachedResponse struct {
+	Code    int               `json:"code"`
+	Headers map[string]string `json:"headers"`
+	Body    []byte            `json:"body"`
+	ETag    string            `json:"etag"`
+}
+
+// ResponseCacheInterface keeps whole responses of public reads. Invalidate
+// drops all of them at once by moving every key to a new version, which is
+// cheap enough to call on every write to the catalogue. A response stored
+// under a version read before an invalidation is never read again.
+type ResponseCacheInterface interface {
+	Get(ctx context.Context, key string) (*CachedResponse, string, bool)
+	Set(ctx context.Context, key, version string, res *CachedResponse)
+	Invalidate(ctx context.Context) error
+}
+
+type responseCache struct {
+	redis redis.RedisInterface
+	ttl   time.Duration
+}
+
+func NewResponseCache(redis redis.RedisInterface, ttl time.Duration) ResponseCacheInterface {
+	return &responseCache{redis, ttl}
+}
+
+// Get returns the cached response of key if any, together with the version
+// it must be stored under when it is missing.
+func (c *responseCache) Get(ctx context.Context, key string) (*CachedResponse, string, bool) {
+	version, err := c.redis.Get(ctx, responseVersionKey)
+
+	if err != nil {
+		return nil, "", false
+	}
+
+	if version == "" {
+		version = "0"
+	}
+
+	cached, err := c.redis.Get(ctx, c.key(version, key))
+
+	if err != nil || cached == "" {
+		return nil, version, false
+	}
+
+	var res CachedResponse
+
+	if err := json.Unmarshal([]byte(cached), &res); err != nil {
+		return nil, version, false
+	}
+
+	return &res, version, true
+}
+
+func (c *responseCache) Set(ctx context.Context, key, version string, res *CachedResponse) {
+	if version == "" {
+		return
+	}
+
+	payload, err := json.Marshal(res)
+
+	if err != nil {
+		return
+	}
+
+	c.redis.Set(ctx, c.key(version, key), payload, c.ttl)
+}
+
+func (c *responseCache) Invalidate(ctx context.Context) error {
+	_, err := c.redis.Incr(ctx, responseVersionKey, responseVersionTTL)
+
+	return err
+}
+
+func (c *responseCache) key(version, key string) string {
+	return "responses:" + version + ":" + key
+}
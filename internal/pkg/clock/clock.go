@@ -0,0 +1,84 @@
+package clock
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	// embeds the zone database so the canteen timezone also works on
+	// images without system zoneinfo
+	_ "time/tzdata"
+
+	"github.com/devanfer02/filkom-canteen/internal/infra/env"
+	"github.com/devanfer02/filkom-canteen/internal/pkg/log"
+)
+
+const (
+	DefaultTimezone = "Asia/Jakarta"
+	DateLayout      = "2006-01-02"
+)
+
+var (
+	location *time.Location
+	once     sync.Once
+)
+
+// Location is the timezone the canteen runs in, every business day and
+// opening hour is evaluated in it.
+func Location() *time.Location {
+	once.Do(func() {
+		name := env.AppEnv.AppTimezone
+
+		if name == "" {
+			name = DefaultTimezone
+		}
+
+		loc, err := time.LoadLocation(name)
+
+		if err != nil {
+			log.Warn(log.LogInfo{
+				"error":    err.Error(),
+				"timezone": name,
+			}, "[CLOCK][Location] failed to load timezone, falling back to default")
+
+			loc, _ = time.LoadLocation(DefaultTimezone)
+		}
+
+		location = loc
+	})
+
+	return location
+}
+
+func Now() time.Time {
+	return time.Now().In(Location())
+}
+
+// Today is the current business date formatted as YYYY-MM-DD.
+func Today() string {
+	return Now().Format(DateLayout)
+}
+
+// ParseTimeOfDay parses a HH:MM clock time into minutes after midnight.
+func ParseTimeOfDay(value string) (int, error) {
+	parsed, err := time.Parse("15:04", value)
+
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %q, expected HH:MM", value)
+	}
+
+	return parsed.Hour()*60 + parsed.Minute(), nil
+}
+
+// Next returns the first moment after now that the clock reads the given
+// minutes after midnight.
+func Next(now time.Time, minutes int) time.Time {
+	year, month, day := now.Date()
+	next := time.Date(year, month, day, minutes/60, minutes%60, 0, 0, now.Location())
+
+	if !next.After(now) {
+		next = time.Date(year, month, day+1, minutes/60, minutes%60, 0, 0, now.Location())
+	}
+
+	return next
+}
@@ -0,0 +1,30 @@
+package enc
+
+import (
+	"encoding/base64"
+	"encoding/json"
+)
+
+// EncodeCursor packs the keyset values of the last returned row into an
+// opaque token clients send back to fetch the next page.
+func EncodeCursor(values ...string) string {
+	raw, _ := json.Marshal(values)
+
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func DecodeCursor(cursor string) ([]string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var values []string
+
+	if err = json.Unmarshal(raw, &values); err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
@@ -0,0 +1,164 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+
+	"github.com/devanfer02/filkom-canteen/internal/pkg/log"
+	"github.com/devanfer02/filkom-canteen/internal/pkg/redis"
+)
+
+// channelPrefix namespaces the redis pub/sub channels, every api instance
+// listens on all of them and hands events to its own subscribers.
+const channelPrefix = "events:"
+
+// BoardTopic carries the queue board updates of every shop.
+const BoardTopic = "board"
+
+// subscriberBuffer is how many events a slow client may lag behind before
+// further events are dropped for it.
+const subscriberBuffer = 16
+
+type Event struct {
+	Topic string          `json:"-"`
+	Type  string          `json:"type"`
+	Data  json.RawMessage `json:"data"`
+}
+
+type BrokerInterface interface {
+	Publish(ctx context.Context, topic, eventType string, data any) error
+	Subscribe(topics ...string) (<-chan Event, func())
+}
+
+type subscriber struct {
+	events chan Event
+}
+
+type redisBroker struct {
+	redis       redis.RedisInterface
+	mu          sync.RWMutex
+	subscribers map[string]map[*subscriber]struct{}
+	once        sync.Once
+}
+
+func NewBroker(redis redis.RedisInterface) BrokerInterface {
+	return &redisBroker{
+		redis:       redis,
+		subscribers: make(map[string]map[*subscriber]struct{}),
+	}
+}
+
+func UserTopic(userID string) string {
+	return "users:" + userID
+}
+
+func ShopTopic(shopID string) string {
+	return "shops:" + shopID
+}
+
+func ShopBoardTopic(shopID string) string {
+	return BoardTopic + ":" + shopID
+}
+
+func NewEvent(eventType string, data any) (Event, error) {
+	raw, err := json.Marshal(data)
+
+	if err != nil {
+		return Event{}, err
+	}
+
+	return Event{Type: eventType, Data: raw}, nil
+}
+
+func (b *redisBroker) Publish(ctx context.Context, topic, eventType string, data any) error {
+	event, err := NewEvent(eventType, data)
+
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(event)
+
+	if err != nil {
+		return err
+	}
+
+	return b.redis.Publish(ctx, channelPrefix+topic, string(payload))
+}
+
+// Subscribe registers a listener for the given topics. The returned func
+// must be called once the listener is gone, it closes the event channel.
+func (b *redisBroker) Subscribe(topics ...string) (<-chan Event, func()) {
+	b.once.Do(func() {
+		go b.listen()
+	})
+
+	sub := &subscriber{events: make(chan Event, subscriberBuffer)}
+
+	b.mu.Lock()
+	for _, topic := range topics {
+		if b.subscribers[topic] == nil {
+			b.subscribers[topic] = make(map[*subscriber]struct{})
+		}
+
+		b.subscribers[topic][sub] = struct{}{}
+	}
+	b.mu.Unlock()
+
+	var unsubscribe sync.Once
+
+	return sub.events, func() {
+		unsubscribe.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+
+			for _, topic := range topics {
+				delete(b.subscribers[topic], sub)
+
+				if len(b.subscribers[topic]) == 0 {
+					delete(b.subscribers, topic)
+				}
+			}
+
+			close(sub.events)
+		})
+	}
+}
+
+// listen holds the single redis subscription of this instance for as long
+// as the process runs.
+func (b *redisBroker) listen() {
+	for msg := range b.redis.PSubscribe(context.Background(), channelPrefix+"*") {
+		var event Event
+
+		if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+			log.Warn(log.LogInfo{
+				"error":   err.Error(),
+				"channel": msg.Channel,
+			}, "[EVENTS][listen] failed to decode event")
+			continue
+		}
+
+		event.Topic = strings.TrimPrefix(msg.Channel, channelPrefix)
+
+		b.dispatch(event)
+	}
+}
+
+func (b *redisBroker) dispatch(event Event) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for sub := range b.subscribers[event.Topic] {
+		select {
+		case sub.events <- event:
+		default:
+			log.Warn(log.LogInfo{
+				"topic": event.Topic,
+				"type":  event.Type,
+			}, "[EVENTS][dispatch] subscriber is lagging behind, event dropped")
+		}
+	}
+}
@@ -1,16 +1,21 @@
 package ginlib
 
 import (
+	"strconv"
+	"strings"
+
 	"github.com/devanfer02/filkom-canteen/domain"
+	"github.com/devanfer02/filkom-canteen/internal/dto"
 	"github.com/gin-gonic/gin"
 )
 
 type Response struct {
-	Code    int         `json:"code"`
-	Status  string      `json:"status"`
-	Message string      `json:"message"`
-	Data    interface{} `json:"data,omitempty"`
-	Err     string      `json:"error,omitempty"`
+	Code       int             `json:"code"`
+	Status     string          `json:"status"`
+	Message    string          `json:"message"`
+	Data       interface{}     `json:"data,omitempty"`
+	Pagination *dto.Pagination `json:"pagination,omitempty"`
+	Err        string          `json:"error,omitempty"`
 }
 
 func SendResponse(
@@ -20,11 +25,39 @@ func SendResponse(
 	data interface{},
 	err error,
 ) {
-	ctx.JSON(code, Response{
-		Code:    code,
-		Status:  status,
-		Message: message,
-		Data:    data,
+	SendPaginatedResponse(ctx, code, status, message, data, nil, err)
+}
+
+func SendPaginatedResponse(
+	ctx *gin.Context,
+	code int,
+	status, message string,
+	data interface{},
+	pagination *dto.Pagination,
+	err error,
+) {
+	if pagination != nil && pagination.NextCursor != "" {
+		ctx.Header("X-Cursor", pagination.NextCursor)
+	}
+
+	ctx.JSON(code, NewResponse(code, status, message, data, pagination, err))
+}
+
+// NewResponse builds the response envelope, for transports that do not reply
+// through gin such as websockets.
+func NewResponse(
+	code int,
+	status, message string,
+	data interface{},
+	pagination *dto.Pagination,
+	err error,
+) Response {
+	return Response{
+		Code:       code,
+		Status:     status,
+		Message:    message,
+		Data:       data,
+		Pagination: pagination,
 		Err: func() string {
 			if err == nil {
 				return ""
@@ -36,7 +69,82 @@ func SendResponse(
 
 			return err.Error()
 		}(),
-	})
+	}
+}
+
+// ParsePage reads the page size from the limit query and the cursor from
+// either the cursor query or the X-Cursor header.
+func ParsePage(ctx *gin.Context) (dto.PageParams, error) {
+	page := dto.PageParams{
+		Cursor: ctx.Query("cursor"),
+	}
+
+	if page.Cursor == "" {
+		page.Cursor = ctx.GetHeader("X-Cursor")
+	}
+
+	if limit := ctx.Query("limit"); limit != "" {
+		parsed, err := strconv.Atoi(limit)
+
+		if err != nil || parsed < 1 {
+			return page, domain.ErrBadRequest
+		}
+
+		page.Limit = parsed
+	}
+
+	return page, nil
+}
+
+// SetETag sends the version of a single item as its ETag, which is what
+// updates of that item expect back in If-Match. States are parts of the item
+// worked out on every request, like whether a shop is open, they change the
+// tag so If-None-Match notices them but are ignored by If-Match.
+func SetETag(ctx *gin.Context, version int64, states ...string) {
+	tag := strconv.FormatInt(version, 10)
+
+	for _, state := range states {
+		tag += "-" + state
+	}
+
+	ctx.Header("ETag", `"`+tag+`"`)
+}
+
+// ParseIfMatch reads the versions an update may overwrite from the If-Match
+// header, nil means any version. Weak or unknown tags never match, since
+// If-Match compares strongly.
+func ParseIfMatch(ctx *gin.Context) ([]int64, error) {
+	header := strings.TrimSpace(ctx.GetHeader("If-Match"))
+
+	if header == "" {
+		return nil, domain.ErrPreconditionRequired
+	}
+
+	if header == "*" {
+		return nil, nil
+	}
+
+	versions := make([]int64, 0, 1)
+
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+
+		if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+			continue
+		}
+
+		value, _, _ := strings.Cut(tag[1:len(tag)-1], "-")
+
+		if version, err := strconv.ParseInt(value, 10, 64); err == nil {
+			versions = append(versions, version)
+		}
+	}
+
+	if len(versions) == 0 {
+		return nil, domain.ErrPreconditionFailed
+	}
+
+	return versions, nil
 }
 
 func SendAbortResponse(
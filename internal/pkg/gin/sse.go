@@ -0,0 +1,46 @@
+package ginlib
+
+import (
+	"io"
+	"time"
+
+	"github.com/devanfer02/filkom-canteen/internal/pkg/events"
+	"github.com/gin-gonic/gin"
+)
+
+// sseHeartbeat keeps idle streams below the read timeout of proxies.
+const sseHeartbeat = 25 * time.Second
+
+// SendEventStream writes every event of stream to the client as server-sent
+// events until the client disconnects or the stream is closed.
+func SendEventStream(ctx *gin.Context, stream <-chan events.Event) {
+	ctx.Header("Content-Type", "text/event-stream")
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("Connection", "keep-alive")
+	// stops nginx from buffering the stream
+	ctx.Header("X-Accel-Buffering", "no")
+	ctx.Status(200)
+	ctx.Writer.Flush()
+
+	heartbeat := time.NewTicker(sseHeartbeat)
+	defer heartbeat.Stop()
+
+	ctx.Stream(func(w io.Writer) bool {
+		select {
+		case <-ctx.Request.Context().Done():
+			return false
+		case event, ok := <-stream:
+			if !ok {
+				return false
+			}
+
+			ctx.SSEvent(event.Type, event.Data)
+
+			return true
+		case <-heartbeat.C:
+			_, err := io.WriteString(w, ": ping\n\n")
+
+			return err == nil
+		}
+	})
+}
@@ -0,0 +1,196 @@
+package imaging
+
+import (
+	"bytes"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/HugoSmits86/nativewebp"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+
+	"github.com/devanfer02/filkom-canteen/domain"
+)
+
+const (
+	VariantThumbnail = "thumb"
+	VariantMedium    = "medium"
+
+	// maxPixels guards against decompression bombs, a 5MB file can still
+	// declare an enormous canvas.
+	maxPixels    = 40_000_000
+	maxDimension = 1600
+	jpegQuality  = 85
+)
+
+// variants are generated for every photo, widths are upper bounds so small
+// uploads are never scaled up.
+var variants = []struct {
+	name  string
+	width int
+}{
+	{VariantThumbnail, 240},
+	{VariantMedium, 720},
+}
+
+type Output struct {
+	Key         string
+	ContentType string
+	Data        []byte
+}
+
+// Process decodes an uploaded photo, applies its EXIF orientation and
+// re-encodes it, which drops every metadata block (GPS, device, etc.). It
+// returns the cleaned original stored under key followed by the resized jpeg
+// and webp variants.
+//
+// WebP variants are encoded lossless since builds are CGO free, clients
+// should pick whichever format is smaller for them.
+func Process(key string, r io.Reader) ([]Output, error) {
+	data, err := io.ReadAll(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+
+	if err != nil {
+		return nil, domain.ErrUnsupportedFile
+	}
+
+	if cfg.Width*cfg.Height > maxPixels {
+		return nil, domain.ErrFileTooLarge
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+
+	if err != nil {
+		return nil, domain.ErrUnsupportedFile
+	}
+
+	if format == "jpeg" {
+		img = orient(img, orientation(data))
+	}
+
+	outputs := make([]Output, 0, 1+2*len(variants))
+
+	original, err := encodeOriginal(key, format, fit(img, maxDimension))
+
+	if err != nil {
+		return nil, err
+	}
+
+	outputs = append(outputs, *original)
+
+	for _, variant := range variants {
+		resized := fit(img, variant.width)
+
+		var buf bytes.Buffer
+
+		if err = jpeg.Encode(&buf, flatten(resized), &jpeg.Options{Quality: jpegQuality}); err != nil {
+			return nil, err
+		}
+
+		outputs = append(outputs, Output{
+			Key:         VariantKey(key, variant.name, ".jpg"),
+			ContentType: "image/jpeg",
+			Data:        buf.Bytes(),
+		})
+
+		buf = bytes.Buffer{}
+
+		if err = nativewebp.Encode(&buf, resized, nil); err != nil {
+			return nil, err
+		}
+
+		outputs = append(outputs, Output{
+			Key:         VariantKey(key, variant.name, ".webp"),
+			ContentType: "image/webp",
+			Data:        buf.Bytes(),
+		})
+	}
+
+	return outputs, nil
+}
+
+// VariantKey derives the key of a variant from the original key, e.g.
+// menus/abc.png becomes menus/abc_thumb.webp.
+func VariantKey(key, variant, ext string) string {
+	return strings.TrimSuffix(key, path.Ext(key)) + "_" + variant + ext
+}
+
+// VariantKeys lists every key generated by Process for the original key.
+func VariantKeys(key string) []string {
+	keys := make([]string, 0, 2*len(variants))
+
+	for _, variant := range variants {
+		keys = append(keys, VariantKey(key, variant.name, ".jpg"), VariantKey(key, variant.name, ".webp"))
+	}
+
+	return keys
+}
+
+func encodeOriginal(key, format string, img image.Image) (*Output, error) {
+	var (
+		buf         bytes.Buffer
+		contentType string
+		err         error
+	)
+
+	switch format {
+	case "png":
+		contentType = "image/png"
+		err = png.Encode(&buf, img)
+	case "webp":
+		contentType = "image/webp"
+		err = nativewebp.Encode(&buf, img, nil)
+	default:
+		contentType = "image/jpeg"
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality})
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Output{Key: key, ContentType: contentType, Data: buf.Bytes()}, nil
+}
+
+// fit scales img down so its longest side is at most size.
+func fit(img image.Image, size int) image.Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	if width <= size && height <= size {
+		return img
+	}
+
+	if width >= height {
+		height = height * size / width
+		width = size
+	} else {
+		width = width * size / height
+		height = size
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, max(width, 1), max(height, 1)))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, bounds, draw.Over, nil)
+
+	return dst
+}
+
+// flatten draws img over a white background since jpeg has no alpha channel.
+func flatten(img image.Image) image.Image {
+	bounds := img.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+
+	draw.Draw(dst, dst.Bounds(), image.White, image.Point{}, draw.Src)
+	draw.Draw(dst, dst.Bounds(), img, bounds.Min, draw.Over)
+
+	return dst
+}
@@ -12,8 +12,18 @@ import (
 
 type RedisInterface interface {
 	Set(ctx context.Context, key string, value interface{}, exp time.Duration) error
+	SetNX(ctx context.Context, key string, value interface{}, exp time.Duration) (bool, error)
 	Get(ctx context.Context, key string) (string, error)
+	GetDel(ctx context.Context, key string) (string, error)
+	Incr(ctx context.Context, key string, exp time.Duration) (int64, error)
 	Delete(ctx context.Context, key string) error
+	Publish(ctx context.Context, channel string, message interface{}) error
+	PSubscribe(ctx context.Context, pattern string) <-chan Message
+}
+
+type Message struct {
+	Channel string
+	Payload string
 }
 
 type redisClient struct {
@@ -49,6 +59,26 @@ func (r *redisClient) Set(
 	return nil
 }
 
+// SetNX only sets the key when it does not exist yet and reports whether it did.
+func (r *redisClient) SetNX(
+	ctx context.Context,
+	key string,
+	value interface{},
+	exp time.Duration,
+) (bool, error) {
+	ok, err := r.rdb.SetNX(ctx, key, value, exp).Result()
+
+	if err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[REDIS][SetNX] failed to set key")
+
+		return false, err
+	}
+
+	return ok, nil
+}
+
 func (r *redisClient) Get(ctx context.Context, key string) (string, error) {
 	val, err := r.rdb.Get(ctx, key).Result()
 
@@ -67,6 +97,42 @@ func (r *redisClient) Get(ctx context.Context, key string) (string, error) {
 	return val, nil
 }
 
+// GetDel reads a key and removes it at once, so only one caller gets its value.
+func (r *redisClient) GetDel(ctx context.Context, key string) (string, error) {
+	val, err := r.rdb.GetDel(ctx, key).Result()
+
+	if err == redis.Nil {
+		return "", nil
+	}
+
+	if err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[REDIS][GetDel] failed to get and delete key")
+
+		return "", err
+	}
+
+	return val, nil
+}
+
+// Incr increments a counter and (re)sets its expiry in one round trip.
+func (r *redisClient) Incr(ctx context.Context, key string, exp time.Duration) (int64, error) {
+	pipe := r.rdb.TxPipeline()
+	incr := pipe.Incr(ctx, key)
+	pipe.Expire(ctx, key, exp)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[REDIS][Incr] failed to increment key")
+
+		return 0, err
+	}
+
+	return incr.Val(), nil
+}
+
 func (r *redisClient) Delete(ctx context.Context, key string) error {
 	err := r.rdb.Del(ctx, key).Err()
 
@@ -80,3 +146,51 @@ func (r *redisClient) Delete(ctx context.Context, key string) error {
 
 	return nil
 }
+
+func (r *redisClient) Publish(ctx context.Context, channel string, message interface{}) error {
+	err := r.rdb.Publish(ctx, channel, message).Err()
+
+	if err != nil {
+		log.Error(log.LogInfo{
+			"error":   err.Error(),
+			"channel": channel,
+		}, "[REDIS][Publish] failed to publish message")
+
+		return err
+	}
+
+	return nil
+}
+
+// PSubscribe streams the messages of every channel matching pattern until
+// ctx is done. Dropped connections are re-established by the client.
+func (r *redisClient) PSubscribe(ctx context.Context, pattern string) <-chan Message {
+	pubsub := r.rdb.PSubscribe(ctx, pattern)
+	messages := make(chan Message)
+
+	go func() {
+		defer close(messages)
+		defer pubsub.Close()
+
+		received := pubsub.Channel()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-received:
+				if !ok {
+					return
+				}
+
+				select {
+				case messages <- Message{Channel: msg.Channel, Payload: msg.Payload}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return messages
+}
@@ -0,0 +1,200 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"mime"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/devanfer02/filkom-canteen/domain"
+	"github.com/devanfer02/filkom-canteen/internal/infra/env"
+	"github.com/devanfer02/filkom-canteen/internal/pkg/log"
+)
+
+type localStorage struct {
+	root    string
+	baseURL string
+}
+
+func newLocalStorage() StorageInterface {
+	return &localStorage{
+		root:    LocalPath(),
+		baseURL: strings.TrimRight(env.AppEnv.AppUrl, "/") + "/files",
+	}
+}
+
+// LocalPath is the directory local uploads are written to and served from.
+func LocalPath() string {
+	if env.AppEnv.StoragePath == "" {
+		return "./internal/data/uploads"
+	}
+
+	return env.AppEnv.StoragePath
+}
+
+func (s *localStorage) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	dst, err := s.path(key)
+
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[STORAGE][Put] failed to create directory")
+		return err
+	}
+
+	// write to a temporary file first so readers never see a partial upload
+	tmp, err := os.CreateTemp(filepath.Dir(dst), ".upload-*")
+
+	if err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[STORAGE][Put] failed to create temporary file")
+		return err
+	}
+
+	defer os.Remove(tmp.Name())
+
+	if _, err = io.Copy(tmp, body); err != nil {
+		tmp.Close()
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[STORAGE][Put] failed to write file")
+		return err
+	}
+
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+
+	if err = os.Rename(tmp.Name(), dst); err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[STORAGE][Put] failed to move file")
+		return err
+	}
+
+	return nil
+}
+
+func (s *localStorage) Get(ctx context.Context, key string) (*Object, error) {
+	dst, err := s.path(key)
+
+	if err != nil {
+		return nil, domain.ErrNotFound
+	}
+
+	file, err := os.Open(dst)
+
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, domain.ErrNotFound
+	}
+
+	if err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[STORAGE][Get] failed to open file")
+		return nil, err
+	}
+
+	info, err := file.Stat()
+
+	if err != nil || info.IsDir() {
+		file.Close()
+		return nil, domain.ErrNotFound
+	}
+
+	return &Object{
+		Body:        file,
+		Size:        info.Size(),
+		ContentType: mime.TypeByExtension(filepath.Ext(dst)),
+		ModTime:     info.ModTime(),
+	}, nil
+}
+
+func (s *localStorage) Delete(ctx context.Context, key string) error {
+	dst, err := s.path(key)
+
+	if err != nil {
+		return err
+	}
+
+	if err = os.Remove(dst); err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[STORAGE][Delete] failed to delete file")
+		return err
+	}
+
+	return nil
+}
+
+func (s *localStorage) URL(key string) string {
+	if isAbsoluteURL(key) {
+		return key
+	}
+
+	return s.baseURL + "/" + strings.TrimLeft(key, "/")
+}
+
+func (s *localStorage) SignedURL(ctx context.Context, key string, exp time.Duration) (string, error) {
+	if isAbsoluteURL(key) {
+		return key, nil
+	}
+
+	expires := strconv.FormatInt(time.Now().Add(exp).Unix(), 10)
+
+	query := url.Values{}
+	query.Set("expires", expires)
+	query.Set("signature", sign(key, expires))
+
+	return s.URL(key) + "?" + query.Encode(), nil
+}
+
+// VerifySignature checks a signed url issued by the local storage.
+func VerifySignature(key, expires, signature string) bool {
+	unix, err := strconv.ParseInt(expires, 10, 64)
+
+	if err != nil || time.Now().Unix() > unix {
+		return false
+	}
+
+	return hmac.Equal([]byte(sign(key, expires)), []byte(signature))
+}
+
+func sign(key, expires string) string {
+	secret := env.AppEnv.StorageKey
+
+	if secret == "" {
+		secret = env.AppEnv.JWTKey
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(key + ":" + expires))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *localStorage) path(key string) (string, error) {
+	dst := filepath.Join(s.root, filepath.FromSlash(key))
+
+	rel, err := filepath.Rel(s.root, dst)
+
+	if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return "", errors.New("invalid storage key")
+	}
+
+	return dst, nil
+}
@@ -0,0 +1,178 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+
+	"github.com/devanfer02/filkom-canteen/domain"
+	"github.com/devanfer02/filkom-canteen/internal/infra/env"
+	"github.com/devanfer02/filkom-canteen/internal/pkg/log"
+)
+
+type s3Storage struct {
+	client    *minio.Client
+	bucket    string
+	publicURL string
+}
+
+func newS3Storage() StorageInterface {
+	client, err := minio.New(env.AppEnv.S3Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(env.AppEnv.S3AccessKey, env.AppEnv.S3SecretKey, ""),
+		Secure: env.AppEnv.S3UseSSL,
+		Region: env.AppEnv.S3Region,
+	})
+
+	if err != nil {
+		log.Fatal(log.LogInfo{
+			"error": err.Error(),
+		}, "[STORAGE][newS3Storage] failed to create s3 client")
+	}
+
+	publicURL := env.AppEnv.S3PublicURL
+
+	if publicURL == "" {
+		publicURL = client.EndpointURL().String() + "/" + env.AppEnv.S3Bucket
+	}
+
+	s := &s3Storage{
+		client:    client,
+		bucket:    env.AppEnv.S3Bucket,
+		publicURL: strings.TrimRight(publicURL, "/"),
+	}
+
+	s.ensureBucket()
+
+	return s
+}
+
+// ensureBucket creates the bucket on first start, e.g. against a fresh local
+// MinIO, and opens only the public prefixes for anonymous reads.
+func (s *s3Storage) ensureBucket() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	exists, err := s.client.BucketExists(ctx, s.bucket)
+
+	if err != nil {
+		log.Fatal(log.LogInfo{
+			"error": err.Error(),
+		}, "[STORAGE][ensureBucket] failed to check bucket")
+	}
+
+	if exists {
+		return
+	}
+
+	if err = s.client.MakeBucket(ctx, s.bucket, minio.MakeBucketOptions{Region: env.AppEnv.S3Region}); err != nil {
+		log.Fatal(log.LogInfo{
+			"error": err.Error(),
+		}, "[STORAGE][ensureBucket] failed to create bucket")
+	}
+
+	resources := make([]string, 0, len(publicPrefixes))
+
+	for _, prefix := range publicPrefixes {
+		resources = append(resources, fmt.Sprintf(`"arn:aws:s3:::%s/%s*"`, s.bucket, prefix))
+	}
+
+	policy := fmt.Sprintf(
+		`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"AWS":["*"]},"Action":["s3:GetObject"],"Resource":[%s]}]}`,
+		strings.Join(resources, ","),
+	)
+
+	if err = s.client.SetBucketPolicy(ctx, s.bucket, policy); err != nil {
+		log.Warn(log.LogInfo{
+			"error": err.Error(),
+		}, "[STORAGE][ensureBucket] failed to set public read policy")
+	}
+}
+
+func (s *s3Storage) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	_, err := s.client.PutObject(ctx, s.bucket, key, body, size, minio.PutObjectOptions{
+		ContentType: contentType,
+	})
+
+	if err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[STORAGE][Put] failed to put object")
+		return err
+	}
+
+	return nil
+}
+
+func (s *s3Storage) Get(ctx context.Context, key string) (*Object, error) {
+	obj, err := s.client.GetObject(ctx, s.bucket, key, minio.GetObjectOptions{})
+
+	if err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[STORAGE][Get] failed to get object")
+		return nil, err
+	}
+
+	info, err := obj.Stat()
+
+	if err != nil {
+		obj.Close()
+
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return nil, domain.ErrNotFound
+		}
+
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[STORAGE][Get] failed to stat object")
+		return nil, err
+	}
+
+	return &Object{
+		Body:        obj,
+		Size:        info.Size,
+		ContentType: info.ContentType,
+		ModTime:     info.LastModified,
+	}, nil
+}
+
+func (s *s3Storage) Delete(ctx context.Context, key string) error {
+	if err := s.client.RemoveObject(ctx, s.bucket, key, minio.RemoveObjectOptions{}); err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[STORAGE][Delete] failed to remove object")
+		return err
+	}
+
+	return nil
+}
+
+func (s *s3Storage) URL(key string) string {
+	if isAbsoluteURL(key) {
+		return key
+	}
+
+	return s.publicURL + "/" + strings.TrimLeft(key, "/")
+}
+
+func (s *s3Storage) SignedURL(ctx context.Context, key string, exp time.Duration) (string, error) {
+	if isAbsoluteURL(key) {
+		return key, nil
+	}
+
+	u, err := s.client.PresignedGetObject(ctx, s.bucket, key, exp, nil)
+
+	if err != nil {
+		log.Error(log.LogInfo{
+			"error": err.Error(),
+		}, "[STORAGE][SignedURL] failed to presign object url")
+		return "", err
+	}
+
+	return u.String(), nil
+}
@@ -0,0 +1,70 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/devanfer02/filkom-canteen/internal/infra/env"
+)
+
+// publicPrefixes are key prefixes readable without a signed url, everything
+// else (e.g. payment proofs) is only reachable through SignedURL.
+var publicPrefixes = []string{"shops/", "menus/"}
+
+type Object struct {
+	Body        io.ReadCloser
+	Size        int64
+	ContentType string
+	ModTime     time.Time
+}
+
+type StorageInterface interface {
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (*Object, error)
+	Delete(ctx context.Context, key string) error
+	URL(key string) string
+	SignedURL(ctx context.Context, key string, exp time.Duration) (string, error)
+}
+
+func NewStorage() StorageInterface {
+	switch env.AppEnv.StorageDriver {
+	case "s3":
+		return newS3Storage()
+	default:
+		return newLocalStorage()
+	}
+}
+
+// IsLocal reports whether files are kept on disk and served by the api itself.
+func IsLocal() bool {
+	return env.AppEnv.StorageDriver != "s3"
+}
+
+// IsValidKey rejects keys that are not already in their clean form, such as
+// shops/../payments/x.png, which would pass the public prefix check while
+// pointing somewhere else.
+func IsValidKey(key string) bool {
+	return key != "" && path.Clean(key) == key && !strings.HasPrefix(key, "../") && key != ".."
+}
+
+func IsPublic(key string) bool {
+	if !IsValidKey(key) {
+		return false
+	}
+
+	for _, prefix := range publicPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isAbsoluteURL keeps links stored before uploads were handled by the api working.
+func isAbsoluteURL(key string) bool {
+	return strings.HasPrefix(key, "http://") || strings.HasPrefix(key, "https://")
+}
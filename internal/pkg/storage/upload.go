@@ -0,0 +1,89 @@
+package storage
+
+import (
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"path"
+
+	"github.com/google/uuid"
+
+	"github.com/devanfer02/filkom-canteen/domain"
+	"github.com/devanfer02/filkom-canteen/internal/infra/env"
+)
+
+const defaultMaxUploadMB int64 = 5
+
+var imageExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
+type Image struct {
+	File        multipart.File
+	Size        int64
+	ContentType string
+	Extension   string
+}
+
+// OpenImage opens an uploaded image after checking its size and sniffing its
+// content type from the file bytes, the client supplied header is ignored.
+func OpenImage(fh *multipart.FileHeader) (*Image, error) {
+	if fh == nil {
+		return nil, domain.ErrBadRequest
+	}
+
+	if fh.Size > MaxUploadSize() {
+		return nil, domain.ErrFileTooLarge
+	}
+
+	file, err := fh.Open()
+
+	if err != nil {
+		return nil, err
+	}
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		file.Close()
+		return nil, err
+	}
+
+	contentType := http.DetectContentType(head[:n])
+	ext, ok := imageExtensions[contentType]
+
+	if !ok {
+		file.Close()
+		return nil, domain.ErrUnsupportedFile
+	}
+
+	if _, err = file.Seek(0, io.SeekStart); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return &Image{
+		File:        file,
+		Size:        fh.Size,
+		ContentType: contentType,
+		Extension:   ext,
+	}, nil
+}
+
+// NewKey generates the object key on the server so client file names never
+// end up in storage paths.
+func NewKey(prefix, ext string) string {
+	return path.Join(prefix, uuid.NewString()+ext)
+}
+
+func MaxUploadSize() int64 {
+	if env.AppEnv.StorageMaxMB > 0 {
+		return env.AppEnv.StorageMaxMB << 20
+	}
+
+	return defaultMaxUploadMB << 20
+}
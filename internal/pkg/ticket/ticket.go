@@ -0,0 +1,85 @@
+package ticket
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/devanfer02/filkom-canteen/domain"
+	"github.com/devanfer02/filkom-canteen/internal/pkg/redis"
+)
+
+// ticketTTL only has to cover opening the connection right after issuing
+const ticketTTL = 30 * time.Second
+
+// Ticket is who a ticket was issued to, the same claims Authenticate reads
+// from a token.
+type Ticket struct {
+	UserID string `json:"user_id"`
+	Issuer string `json:"issuer"`
+	Role   string `json:"role"`
+}
+
+// TicketInterface hands out one time, short lived tickets for clients that
+// cannot send headers, such as browser WebSockets.
+type TicketInterface interface {
+	Issue(ctx context.Context, ticket *Ticket) (string, time.Time, error)
+	Redeem(ctx context.Context, token string) (*Ticket, error)
+}
+
+type ticketStore struct {
+	redis redis.RedisInterface
+}
+
+func NewTicketStore(redis redis.RedisInterface) TicketInterface {
+	return &ticketStore{redis}
+}
+
+func (s *ticketStore) Issue(ctx context.Context, ticket *Ticket) (string, time.Time, error) {
+	random := make([]byte, 32)
+
+	if _, err := rand.Read(random); err != nil {
+		return "", time.Time{}, err
+	}
+
+	payload, err := json.Marshal(ticket)
+
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	token := hex.EncodeToString(random)
+
+	if err := s.redis.Set(ctx, s.key(token), payload, ticketTTL); err != nil {
+		return "", time.Time{}, err
+	}
+
+	return token, time.Now().Add(ticketTTL), nil
+}
+
+// Redeem returns the ticket of token and burns it, a ticket works only once.
+func (s *ticketStore) Redeem(ctx context.Context, token string) (*Ticket, error) {
+	if token == "" {
+		return nil, domain.ErrInvalidToken
+	}
+
+	stored, err := s.redis.GetDel(ctx, s.key(token))
+
+	if err != nil {
+		return nil, err
+	}
+
+	var ticket Ticket
+
+	if stored == "" || json.Unmarshal([]byte(stored), &ticket) != nil {
+		return nil, domain.ErrInvalidToken
+	}
+
+	return &ticket, nil
+}
+
+func (s *ticketStore) key(token string) string {
+	return "ticket:" + token
+}
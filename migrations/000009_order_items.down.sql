@@ -0,0 +1,13 @@
+ALTER TABLE orders ADD COLUMN menu_id UUID REFERENCES menus(menu_id);
+
+UPDATE orders SET menu_id = (
+    SELECT order_items.menu_id
+    FROM order_items
+    WHERE order_items.order_id = orders.order_id
+    ORDER BY order_items.order_item_id
+    LIMIT 1
+);
+
+DROP TABLE IF EXISTS order_items;
+
+ALTER TABLE orders DROP COLUMN shop_id;
\ No newline at end of file
@@ -0,0 +1,26 @@
+ALTER TABLE orders ADD COLUMN shop_id UUID REFERENCES shops(shop_id);
+
+CREATE TABLE order_items (
+    order_item_id UUID PRIMARY KEY DEFAULT generate_ulid(),
+    order_id UUID NOT NULL REFERENCES orders(order_id) ON DELETE CASCADE,
+    menu_id UUID REFERENCES menus(menu_id) ON DELETE SET NULL,
+    menu_name VARCHAR(200),
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    unit_price INTEGER NOT NULL,
+    notes TEXT DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX order_items_order_id_idx ON order_items(order_id);
+
+-- move every single-menu order into its own line item, snapshotting the current price
+INSERT INTO order_items (order_id, menu_id, menu_name, quantity, unit_price)
+SELECT orders.order_id, menus.menu_id, menus.menu_name, 1, menus.menu_price
+FROM orders
+JOIN menus ON menus.menu_id = orders.menu_id;
+
+UPDATE orders SET shop_id = menus.shop_id
+FROM menus
+WHERE menus.menu_id = orders.menu_id;
+
+ALTER TABLE orders DROP COLUMN menu_id;
\ No newline at end of file
@@ -0,0 +1,9 @@
+ALTER TABLE orders
+    DROP CONSTRAINT IF EXISTS orders_status_fkey,
+    ALTER COLUMN status DROP NOT NULL,
+    ALTER COLUMN status DROP DEFAULT;
+
+DELETE FROM order_status
+WHERE status_name IN ('Waiting', 'Accepted', 'Preparing', 'Ready', 'PickedUp', 'Cancelled', 'Rejected');
+
+ALTER TABLE order_status DROP CONSTRAINT IF EXISTS order_status_name_key;
\ No newline at end of file
@@ -0,0 +1,35 @@
+ALTER TABLE order_status ADD CONSTRAINT order_status_name_key UNIQUE (status_name);
+
+INSERT INTO order_status (status_name) VALUES
+    ('Waiting'),
+    ('Accepted'),
+    ('Preparing'),
+    ('Ready'),
+    ('PickedUp'),
+    ('Cancelled'),
+    ('Rejected')
+ON CONFLICT (status_name) DO NOTHING;
+
+-- statuses used to be free text, so map the spellings we know and close
+-- anything else instead of putting it back in the kitchen queue
+UPDATE orders SET status = CASE lower(regexp_replace(status, '[\s_-]', '', 'g'))
+    WHEN 'waiting' THEN 'Waiting'
+    WHEN 'pending' THEN 'Waiting'
+    WHEN 'accepted' THEN 'Accepted'
+    WHEN 'preparing' THEN 'Preparing'
+    WHEN 'processing' THEN 'Preparing'
+    WHEN 'ready' THEN 'Ready'
+    WHEN 'pickedup' THEN 'PickedUp'
+    WHEN 'completed' THEN 'PickedUp'
+    WHEN 'done' THEN 'PickedUp'
+    WHEN 'cancelled' THEN 'Cancelled'
+    WHEN 'canceled' THEN 'Cancelled'
+    WHEN 'rejected' THEN 'Rejected'
+    ELSE 'Cancelled'
+END
+WHERE status IS NULL OR status NOT IN (SELECT status_name FROM order_status);
+
+ALTER TABLE orders
+    ALTER COLUMN status SET DEFAULT 'Waiting',
+    ALTER COLUMN status SET NOT NULL,
+    ADD CONSTRAINT orders_status_fkey FOREIGN KEY (status) REFERENCES order_status(status_name);
\ No newline at end of file
@@ -0,0 +1,12 @@
+CREATE TABLE order_status_history (
+    history_id UUID PRIMARY KEY DEFAULT generate_ulid(),
+    order_id UUID NOT NULL REFERENCES orders(order_id) ON DELETE CASCADE,
+    actor_id UUID,
+    actor_type VARCHAR(50),
+    old_status VARCHAR(100) REFERENCES order_status(status_name),
+    new_status VARCHAR(100) NOT NULL REFERENCES order_status(status_name),
+    reason TEXT DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX order_status_history_order_id_idx ON order_status_history(order_id);
\ No newline at end of file
@@ -0,0 +1,7 @@
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS payment_verified_at,
+    DROP COLUMN IF EXISTS payment_verified_by,
+    DROP COLUMN IF EXISTS payment_note,
+    DROP COLUMN IF EXISTS payment_status;
+
+DROP TYPE IF EXISTS PAYMENT_STATUS_ENUM;
\ No newline at end of file
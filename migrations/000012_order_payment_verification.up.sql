@@ -0,0 +1,10 @@
+CREATE TYPE PAYMENT_STATUS_ENUM AS ENUM('Unpaid', 'Submitted', 'Approved', 'Rejected');
+
+ALTER TABLE orders
+    ADD COLUMN payment_status PAYMENT_STATUS_ENUM NOT NULL DEFAULT 'Unpaid',
+    ADD COLUMN payment_note TEXT DEFAULT '',
+    ADD COLUMN payment_verified_by UUID REFERENCES admins(admin_id),
+    ADD COLUMN payment_verified_at TIMESTAMP;
+
+UPDATE orders SET payment_status = 'Submitted'
+WHERE payment_proof_link IS NOT NULL AND payment_proof_link <> '';
\ No newline at end of file
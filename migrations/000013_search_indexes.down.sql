@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS shops_description_trgm_idx;
+DROP INDEX IF EXISTS shops_name_trgm_idx;
+DROP INDEX IF EXISTS shops_tsv_idx;
+DROP INDEX IF EXISTS menus_name_trgm_idx;
+DROP INDEX IF EXISTS menus_name_tsv_idx;
+
+DROP EXTENSION IF EXISTS pg_trgm;
\ No newline at end of file
@@ -0,0 +1,16 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS menus_name_tsv_idx ON menus
+    USING GIN (to_tsvector('simple', COALESCE(menu_name, '')));
+
+CREATE INDEX IF NOT EXISTS menus_name_trgm_idx ON menus
+    USING GIN (COALESCE(menu_name, '') gin_trgm_ops);
+
+CREATE INDEX IF NOT EXISTS shops_tsv_idx ON shops
+    USING GIN (to_tsvector('simple', COALESCE(shop_name, '') || ' ' || COALESCE(shop_description, '')));
+
+CREATE INDEX IF NOT EXISTS shops_name_trgm_idx ON shops
+    USING GIN (COALESCE(shop_name, '') gin_trgm_ops);
+
+CREATE INDEX IF NOT EXISTS shops_description_trgm_idx ON shops
+    USING GIN (COALESCE(shop_description, '') gin_trgm_ops);
\ No newline at end of file
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS menus_category_id_idx;
+
+ALTER TABLE menus DROP COLUMN IF EXISTS category_id;
+
+DROP TABLE IF EXISTS menu_categories;
\ No newline at end of file
@@ -0,0 +1,16 @@
+CREATE TABLE menu_categories (
+    category_id UUID PRIMARY KEY DEFAULT generate_ulid(),
+    shop_id UUID NOT NULL REFERENCES shops(shop_id) ON DELETE CASCADE,
+    category_name VARCHAR(100) NOT NULL,
+    display_order INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(shop_id, category_name)
+);
+
+CREATE INDEX menu_categories_shop_order_idx ON menu_categories(shop_id, display_order);
+
+ALTER TABLE menus
+    ADD COLUMN category_id UUID REFERENCES menu_categories(category_id) ON DELETE SET NULL;
+
+CREATE INDEX menus_category_id_idx ON menus(category_id);
\ No newline at end of file
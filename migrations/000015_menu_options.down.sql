@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS order_item_options;
+
+DROP TABLE IF EXISTS menu_option_values;
+
+DROP TABLE IF EXISTS menu_option_groups;
\ No newline at end of file
@@ -0,0 +1,39 @@
+CREATE TABLE menu_option_groups (
+    option_group_id UUID PRIMARY KEY DEFAULT generate_ulid(),
+    menu_id UUID NOT NULL REFERENCES menus(menu_id) ON DELETE CASCADE,
+    group_name VARCHAR(100) NOT NULL,
+    min_select INTEGER NOT NULL DEFAULT 0 CHECK (min_select >= 0),
+    max_select INTEGER NOT NULL DEFAULT 1 CHECK (max_select >= 1),
+    is_required BOOLEAN NOT NULL DEFAULT FALSE,
+    display_order INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (max_select >= min_select)
+);
+
+CREATE INDEX menu_option_groups_menu_id_idx ON menu_option_groups(menu_id, display_order);
+
+CREATE TABLE menu_option_values (
+    option_value_id UUID PRIMARY KEY DEFAULT generate_ulid(),
+    option_group_id UUID NOT NULL REFERENCES menu_option_groups(option_group_id) ON DELETE CASCADE,
+    value_name VARCHAR(100) NOT NULL,
+    price_delta INTEGER NOT NULL DEFAULT 0,
+    is_available BOOLEAN NOT NULL DEFAULT TRUE,
+    display_order INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX menu_option_values_group_id_idx ON menu_option_values(option_group_id, display_order);
+
+-- selected options are snapshotted like the item price, so editing or
+-- removing an option never changes a past order
+CREATE TABLE order_item_options (
+    order_item_option_id UUID PRIMARY KEY DEFAULT generate_ulid(),
+    order_item_id UUID NOT NULL REFERENCES order_items(order_item_id) ON DELETE CASCADE,
+    option_value_id UUID REFERENCES menu_option_values(option_value_id) ON DELETE SET NULL,
+    group_name VARCHAR(100) NOT NULL,
+    value_name VARCHAR(100) NOT NULL,
+    price_delta INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX order_item_options_item_id_idx ON order_item_options(order_item_id);
\ No newline at end of file
@@ -0,0 +1,4 @@
+ALTER TABLE menus
+    DROP COLUMN IF EXISTS stock_reset_on,
+    DROP COLUMN IF EXISTS stock_remaining,
+    DROP COLUMN IF EXISTS daily_stock;
\ No newline at end of file
@@ -0,0 +1,7 @@
+-- a NULL daily_stock means the menu is not stock tracked
+ALTER TABLE menus
+    ADD COLUMN daily_stock INTEGER,
+    ADD COLUMN stock_remaining INTEGER,
+    ADD COLUMN stock_reset_on DATE,
+    ADD CONSTRAINT menus_daily_stock_check CHECK (daily_stock >= 0),
+    ADD CONSTRAINT menus_stock_remaining_check CHECK (stock_remaining >= 0);
\ No newline at end of file
@@ -0,0 +1,7 @@
+ALTER TABLE shops
+    DROP COLUMN IF EXISTS closed_reason,
+    DROP COLUMN IF EXISTS manually_closed;
+
+DROP TABLE IF EXISTS shop_closures;
+
+DROP TABLE IF EXISTS shop_hours;
\ No newline at end of file
@@ -0,0 +1,25 @@
+-- weekday follows Go and PostgreSQL DOW numbering, 0 is Sunday. A day may
+-- have several intervals, e.g. a break between lunch and dinner.
+CREATE TABLE shop_hours (
+    shop_hour_id UUID PRIMARY KEY DEFAULT generate_ulid(),
+    shop_id UUID NOT NULL REFERENCES shops(shop_id) ON DELETE CASCADE,
+    weekday SMALLINT NOT NULL CHECK (weekday BETWEEN 0 AND 6),
+    open_time TIME NOT NULL,
+    close_time TIME NOT NULL,
+    CHECK (open_time < close_time)
+);
+
+CREATE INDEX shop_hours_shop_id_idx ON shop_hours(shop_id, weekday);
+
+CREATE TABLE shop_closures (
+    closure_id UUID PRIMARY KEY DEFAULT generate_ulid(),
+    shop_id UUID NOT NULL REFERENCES shops(shop_id) ON DELETE CASCADE,
+    closed_on DATE NOT NULL,
+    reason VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(shop_id, closed_on)
+);
+
+ALTER TABLE shops
+    ADD COLUMN manually_closed BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN closed_reason VARCHAR(255) NOT NULL DEFAULT '';
\ No newline at end of file
@@ -0,0 +1,5 @@
+ALTER TABLE shops DROP COLUMN IF EXISTS pickup_slot_capacity;
+
+DROP INDEX IF EXISTS orders_shop_pickup_idx;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS pickup_at;
\ No newline at end of file
@@ -0,0 +1,9 @@
+-- a NULL pickup_at means the order is picked up as soon as it is ready
+ALTER TABLE orders ADD COLUMN pickup_at TIMESTAMPTZ;
+
+CREATE INDEX orders_shop_pickup_idx ON orders(shop_id, pickup_at) WHERE pickup_at IS NOT NULL;
+
+-- a NULL capacity leaves the pickup slots of a shop unlimited
+ALTER TABLE shops
+    ADD COLUMN pickup_slot_capacity INTEGER,
+    ADD CONSTRAINT shops_pickup_slot_capacity_check CHECK (pickup_slot_capacity > 0);
\ No newline at end of file
@@ -0,0 +1,6 @@
+ALTER TABLE orders
+    DROP CONSTRAINT IF EXISTS orders_queue_number_key,
+    DROP COLUMN IF EXISTS queue_date,
+    DROP COLUMN IF EXISTS queue_number;
+
+DROP TABLE IF EXISTS shop_queue_counters;
\ No newline at end of file
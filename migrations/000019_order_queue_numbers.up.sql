@@ -0,0 +1,13 @@
+-- one counter row per shop and business day, the upsert on it serializes
+-- concurrent orders of the same shop so numbers are never handed out twice
+CREATE TABLE shop_queue_counters (
+    shop_id UUID NOT NULL REFERENCES shops(shop_id) ON DELETE CASCADE,
+    queue_date DATE NOT NULL,
+    last_number INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (shop_id, queue_date)
+);
+
+ALTER TABLE orders
+    ADD COLUMN queue_number INTEGER,
+    ADD COLUMN queue_date DATE,
+    ADD CONSTRAINT orders_queue_number_key UNIQUE (shop_id, queue_date, queue_number);
\ No newline at end of file
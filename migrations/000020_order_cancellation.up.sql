@@ -0,0 +1,6 @@
+-- cancelled orders are kept for the sales history instead of being deleted
+ALTER TABLE orders
+    ADD COLUMN cancelled_at TIMESTAMPTZ,
+    ADD COLUMN cancelled_by UUID,
+    ADD COLUMN cancelled_by_type VARCHAR(50),
+    ADD COLUMN cancel_reason VARCHAR(255);
\ No newline at end of file
@@ -0,0 +1,4 @@
+ALTER TABLE orders DROP COLUMN IF EXISTS version;
+ALTER TABLE admins DROP COLUMN IF EXISTS version;
+ALTER TABLE menus DROP COLUMN IF EXISTS version;
+ALTER TABLE shops DROP COLUMN IF EXISTS version;
\ No newline at end of file
@@ -0,0 +1,5 @@
+-- bumped on every update, updates sent with If-Match only apply to the version they were read at
+ALTER TABLE shops ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE menus ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE admins ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE orders ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
\ No newline at end of file
@@ -13,7 +13,9 @@ export let options = {
 export default function() {
     const url = 'http://localhost:5700/api/v1/orders'
     const payload = JSON.stringify({
-        menu_id: 'MDE5M2JhZWEtNDYzNi0xOTQ5LTNmYTUtNmIxNmQ1NTBlM2I0',
+        items: [
+            { menu_id: 'MDE5M2JhZWEtNDYzNi0xOTQ5LTNmYTUtNmIxNmQ1NTBlM2I0', quantity: 1 }
+        ],
         payment_method: 'COD'
     })
     const params = {